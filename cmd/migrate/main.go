@@ -0,0 +1,84 @@
+package main
+
+import (
+	"awesomeProject/internal/repository"
+	"awesomeProject/pkg/config"
+	"awesomeProject/pkg/db"
+	"database/sql"
+	"flag"
+	_ "github.com/lib/pq"
+	log "github.com/sirupsen/logrus"
+	_ "modernc.org/sqlite"
+)
+
+// step rewrites documents stored in an older shape. Steps only touch
+// documents still in the old shape, so running them again is safe. A step
+// without a function for a driver has nothing to do there, usually because
+// MigrateSQL already covers it.
+type step struct {
+	name  string
+	mongo func(store db.Store, opts options) (int, error)
+	sql   func(conn *sql.DB, opts options) (int, error)
+}
+
+type options struct {
+	// currency is assumed for amounts stored before they carried one.
+	currency string
+}
+
+var steps = []step{
+	{name: "money", mongo: migrateMoneyMongo, sql: migrateMoneySQL},
+	{name: "unique", mongo: migrateUniqueMongo},
+	{name: "timestamps", mongo: migrateTimestampsMongo},
+}
+
+// The migrate command rewrites existing data for the current entity shapes.
+// It reads the same config profiles as the server; run it before starting
+// a server that needs the new shapes.
+func main() {
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+	opts := options{}
+	flag.StringVar(&opts.currency, "currency", cfg.Pricing.Currency, "currency of amounts stored without one")
+	flag.Parse()
+
+	switch cfg.Store.Driver {
+	case "memory":
+		log.Info("The memory store keeps no data between runs, nothing to migrate")
+	case "mongo":
+		store := db.NewMongoStore(cfg.Mongo)
+		defer store.Close()
+		for _, s := range steps {
+			if s.mongo == nil {
+				continue
+			}
+			n, err := s.mongo(store, opts)
+			report(s.name, n, err)
+		}
+	default:
+		conn, err := sql.Open(cfg.Store.Driver, cfg.Store.DSN)
+		if err != nil {
+			log.Fatalf("Failed to open %s database: %s", cfg.Store.Driver, err)
+		}
+		defer conn.Close()
+		if err := repository.MigrateSQL(conn); err != nil {
+			log.Fatalf("Failed to migrate %s database: %s", cfg.Store.Driver, err)
+		}
+		for _, s := range steps {
+			if s.sql == nil {
+				continue
+			}
+			n, err := s.sql(conn, opts)
+			report(s.name, n, err)
+		}
+	}
+}
+
+func report(name string, n int, err error) {
+	if err != nil {
+		log.Fatalf("Migration %s failed after %d documents: %s", name, n, err)
+	}
+	log.Infof("Migration %s rewrote %d documents", name, n)
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"awesomeProject/internal/entity"
+	"awesomeProject/pkg/db"
+	"awesomeProject/pkg/money"
+	"awesomeProject/pkg/utils"
+	"database/sql"
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// migrateMoneyMongo turns float prices into money amounts. Legacy order
+// items become lines of quantity one, and orders get totals equal to their
+// subtotal since no tax or shipping was charged for them.
+func migrateMoneyMongo(store db.Store, opts options) (int, error) {
+	n := 0
+	var products []bson.M
+	err := store.GetAll(entity.ProductCollectionName, utils.KeyValue{"price.currency": bson.M{"$exists": false}}, &products)
+	if err != nil {
+		return n, err
+	}
+	for _, product := range products {
+		price, err := legacyPrice(product["price"], opts.currency)
+		if err != nil {
+			return n, fmt.Errorf("product %v: %w", product["_id"], err)
+		}
+		product["price"] = price
+		if err := store.Replace(entity.ProductCollectionName, utils.KeyValue{"_id": product["_id"]}, product); err != nil {
+			return n, err
+		}
+		n++
+	}
+
+	var orders []bson.M
+	err = store.GetAll(entity.OrderCollectionName, utils.KeyValue{"total.currency": bson.M{"$exists": false}}, &orders)
+	if err != nil {
+		return n, err
+	}
+	for _, order := range orders {
+		items, _ := order["items"].(bson.A)
+		subtotal := money.Zero(opts.currency)
+		lines := bson.A{}
+		for _, item := range items {
+			line, ok := item.(bson.M)
+			if !ok {
+				continue
+			}
+			price, err := legacyPrice(line["price"], opts.currency)
+			if err != nil {
+				return n, fmt.Errorf("order %v: %w", order["_id"], err)
+			}
+			if _, ok := line["productId"]; !ok {
+				line["productId"] = line["_id"]
+			}
+			delete(line, "_id")
+			delete(line, "price")
+			line["quantity"] = int64(1)
+			line["unitPrice"] = price
+			line["lineTotal"] = price
+			subtotal, _ = subtotal.Add(price)
+			lines = append(lines, line)
+		}
+		order["items"] = lines
+		order["subtotal"] = subtotal
+		order["tax"] = money.Zero(opts.currency)
+		order["shipping"] = money.Zero(opts.currency)
+		order["total"] = subtotal
+		if err := store.Replace(entity.OrderCollectionName, utils.KeyValue{"_id": order["_id"]}, order); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, nil
+}
+
+// legacyPrice converts a float price in major units.
+func legacyPrice(value interface{}, currency string) (money.Money, error) {
+	switch v := value.(type) {
+	case nil:
+		return money.Zero(currency), nil
+	case float64:
+		return money.FromMajor(v, currency), nil
+	case int32:
+		return money.FromMajor(float64(v), currency), nil
+	case int64:
+		return money.FromMajor(float64(v), currency), nil
+	}
+	return money.Money{}, fmt.Errorf("unexpected price %v of type %T", value, value)
+}
+
+// migrateMoneySQL fills the amount columns of migration 3 from the float
+// price columns, for the rows that have no currency yet.
+func migrateMoneySQL(conn *sql.DB, opts options) (int, error) {
+	n := 0
+	products, err := legacyRows(conn, `SELECT id, price FROM products WHERE currency = ''`)
+	if err != nil {
+		return n, err
+	}
+	for id, price := range products {
+		amount := money.FromMajor(price, opts.currency).Amount
+		_, err := conn.Exec(`UPDATE products SET price_amount = $2, currency = $3 WHERE id = $1`, id, amount, opts.currency)
+		if err != nil {
+			return n, err
+		}
+		n++
+	}
+
+	orders, err := legacyRows(conn, `SELECT id, 0 FROM orders WHERE currency = ''`)
+	if err != nil {
+		return n, err
+	}
+	for id := range orders {
+		tx, err := conn.Begin()
+		if err != nil {
+			return n, err
+		}
+		if err := migrateOrderSQL(tx, id, opts.currency); err != nil {
+			_ = tx.Rollback()
+			return n, fmt.Errorf("order %s: %w", id, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, nil
+}
+
+func migrateOrderSQL(tx *sql.Tx, id string, currency string) error {
+	rows, err := tx.Query(`SELECT position, price FROM order_items WHERE order_id = $1`, id)
+	if err != nil {
+		return err
+	}
+	prices := map[int]float64{}
+	for rows.Next() {
+		var (
+			position int
+			price    float64
+		)
+		if err := rows.Scan(&position, &price); err != nil {
+			rows.Close()
+			return err
+		}
+		prices[position] = price
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	var subtotal int64
+	for position, price := range prices {
+		amount := money.FromMajor(price, currency).Amount
+		_, err := tx.Exec(`UPDATE order_items SET quantity = 1, unit_price = $3, line_total = $3
+			WHERE order_id = $1 AND position = $2`, id, position, amount)
+		if err != nil {
+			return err
+		}
+		subtotal += amount
+	}
+	_, err = tx.Exec(`UPDATE orders SET currency = $2, subtotal = $3, tax = 0, shipping = 0, total = $3 WHERE id = $1`,
+		id, currency, subtotal)
+	return err
+}
+
+// legacyRows reads id and float pairs.
+func legacyRows(conn *sql.DB, query string) (map[string]float64, error) {
+	rows, err := conn.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	values := map[string]float64{}
+	for rows.Next() {
+		var (
+			id    string
+			value float64
+		)
+		if err := rows.Scan(&id, &value); err != nil {
+			return nil, err
+		}
+		values[id] = value
+	}
+	return values, rows.Err()
+}
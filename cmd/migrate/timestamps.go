@@ -0,0 +1,67 @@
+package main
+
+import (
+	"awesomeProject/internal/entity"
+	"awesomeProject/pkg/db"
+	"awesomeProject/pkg/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+// migrateTimestampsMongo rewrites the primitive.Timestamp fields of orders,
+// order lines, customers and products as dates, which range queries and
+// sorting compare against, and stamps updatedAt from createdAt where it is
+// missing. SQL migration 5 does the same for the SQL stores.
+func migrateTimestampsMongo(store db.Store, opts options) (int, error) {
+	n := 0
+	for _, collection := range []string{entity.OrderCollectionName, entity.CustomerCollectionName, entity.ProductCollectionName} {
+		var docs []bson.M
+		if err := store.GetAll(collection, utils.KeyValue{}, &docs); err != nil {
+			return n, err
+		}
+		for _, doc := range docs {
+			changed := convertTimestamps(doc, "createdAt", "deliveryDate", "updatedAt")
+			if items, ok := doc["items"].(bson.A); ok {
+				for _, item := range items {
+					if line, ok := item.(bson.M); ok && convertTimestamps(line, "createdAt") {
+						changed = true
+					}
+				}
+			}
+			if _, ok := doc["updatedAt"]; !ok {
+				doc["updatedAt"] = doc["createdAt"]
+				changed = true
+			}
+			if !changed {
+				continue
+			}
+			if err := store.Replace(collection, utils.KeyValue{"_id": doc["_id"]}, doc); err != nil {
+				return n, err
+			}
+			n++
+		}
+	}
+	return n, nil
+}
+
+// convertTimestamps replaces the named fields holding a timestamp with the
+// equivalent date, reporting whether any was replaced.
+func convertTimestamps(doc bson.M, fields ...string) bool {
+	changed := false
+	for _, field := range fields {
+		ts, ok := doc[field].(primitive.Timestamp)
+		if !ok {
+			continue
+		}
+		// Unset timestamps become the zero time.Time, as the entities
+		// store it.
+		var date time.Time
+		if !ts.IsZero() {
+			date = time.Unix(int64(ts.T), 0)
+		}
+		doc[field] = primitive.NewDateTimeFromTime(date)
+		changed = true
+	}
+	return changed
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"awesomeProject/internal/entity"
+	"awesomeProject/pkg/db"
+	"awesomeProject/pkg/utils"
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"sort"
+)
+
+// migrateUniqueMongo frees the order numbers and SKUs that the unique
+// indexes created at server start need. The oldest document keeps a shared
+// value and the others get their id appended, as SQL migration 4 does for
+// order numbers.
+func migrateUniqueMongo(store db.Store, opts options) (int, error) {
+	n := 0
+	for _, unique := range []struct{ collection, field string }{
+		{entity.OrderCollectionName, "orderNo"},
+		{entity.ProductCollectionName, "sku"},
+	} {
+		var docs []bson.M
+		err := store.GetAll(unique.collection, utils.KeyValue{unique.field: bson.M{"$gt": ""}}, &docs)
+		if err != nil {
+			return n, err
+		}
+		sort.Slice(docs, func(i, j int) bool {
+			return fmt.Sprint(docs[i]["_id"]) < fmt.Sprint(docs[j]["_id"])
+		})
+		seen := map[string]bool{}
+		for _, doc := range docs {
+			value, _ := doc[unique.field].(string)
+			if !seen[value] {
+				seen[value] = true
+				continue
+			}
+			id, ok := doc["_id"].(primitive.ObjectID)
+			if !ok {
+				return n, fmt.Errorf("%s %v: unexpected id", unique.collection, doc["_id"])
+			}
+			doc[unique.field] = value + "-" + id.Hex()
+			if err := store.Replace(unique.collection, utils.KeyValue{"_id": id}, doc); err != nil {
+				return n, err
+			}
+			n++
+		}
+	}
+	return n, nil
+}
@@ -1,58 +1,227 @@
 package main
 
 import (
+	"awesomeProject/internal/entity"
+	"awesomeProject/internal/events"
+	"awesomeProject/internal/idempotency"
+	"awesomeProject/internal/interceptors"
+	"awesomeProject/internal/metrics"
+	"awesomeProject/internal/orderno"
 	"awesomeProject/internal/orders"
 	"awesomeProject/internal/orders/pb"
+	"awesomeProject/internal/outbox"
+	"awesomeProject/internal/products"
+	"awesomeProject/internal/repository"
+	"awesomeProject/internal/webhooks"
 	"awesomeProject/pkg/cache"
+	"awesomeProject/pkg/config"
 	"awesomeProject/pkg/db"
+	"context"
+	"database/sql"
+	_ "github.com/lib/pq"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
+	_ "modernc.org/sqlite"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
+	"time"
 )
 
 func main() {
 	log.SetFormatter(&log.TextFormatter{})
 
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "7100"
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
 	}
-	log.Warning("Our current port is ", port)
-	listener, err := net.Listen("tcp", ":"+port)
+	log.Warningf("Loaded the %s config, our current port is %s", cfg.Env, cfg.Port)
+	listener, err := net.Listen("tcp", ":"+cfg.Port)
 	if err != nil {
 		log.WithFields(log.Fields{
 			"new error": err,
 		}).Info("Listener failed")
 	}
-	cache := cache.InitRedisCache()
-
-	mongo := db.NewMongoStore()
-	s := grpc.NewServer()
-	server := orders.NewOrderServer(&log.Logger{}, mongo, cache)
+	appMetrics := metrics.New()
+	repos, orderCache, closeStore := openStore(cfg, appMetrics)
+	numbers, err := orderno.NewGenerator(cfg.OrderNumbers.Format, orderSequence(cfg, repos, orderCache))
+	if err != nil {
+		log.Fatalf("Invalid orderNumbers.format: %s", err)
+	}
+	ctx, stop := context.WithCancel(context.Background())
+	broadcaster := events.NewBroadcaster(log.StandardLogger(), eventSource(ctx, cfg, repos, orderCache), events.Options{
+		Buffer:  cfg.Streams.Buffer,
+		Policy:  events.Policy(cfg.Streams.SlowConsumer),
+		History: cfg.Streams.History,
+	})
+	go broadcaster.Run(ctx)
+	appMetrics.WatchBroadcaster(broadcaster)
+	publisher := outbox.Publishers{outboxPublisher(cfg, orderCache), webhooks.NewDispatcher(log.StandardLogger(), repos)}
+	go outbox.NewRelay(log.StandardLogger(), repos.Outbox, publisher, outbox.Options{
+		Interval:  cfg.Outbox.Interval,
+		Batch:     cfg.Outbox.Batch,
+		Retention: cfg.Outbox.Retention,
+	}).Run(ctx)
+	go webhooks.NewWorker(log.StandardLogger(), repos, nil, webhooks.Options{
+		Interval:    cfg.Webhooks.Interval,
+		Batch:       cfg.Webhooks.Batch,
+		Timeout:     cfg.Webhooks.Timeout,
+		MaxAttempts: cfg.Webhooks.MaxAttempts,
+		Backoff:     cfg.Webhooks.Backoff,
+		MaxBackoff:  cfg.Webhooks.MaxBackoff,
+	}).Run(ctx)
+	s := grpc.NewServer(interceptors.ServerOptions(log.StandardLogger(), cfg.GRPC.Timeout, appMetrics)...)
+	server := orders.NewOrderServer(log.StandardLogger(), repos, cache.NewObservedCache(orderCache, appMetrics.CacheObserver("orders")), entity.Pricing{
+		Currency:         cfg.Pricing.Currency,
+		TaxRate:          cfg.Pricing.TaxRate,
+		ShippingFee:      cfg.Pricing.ShippingFee,
+		FreeShippingFrom: cfg.Pricing.FreeShippingFrom,
+	}, numbers, broadcaster, idempotency.NewStore(log.StandardLogger(), cache.NewObservedCache(orderCache, appMetrics.CacheObserver("idempotency")), cfg.Idempotency.Window))
 	reflection.Register(s)
 	pb.RegisterOrdersServer(s, server)
+	pb.RegisterProductsServer(s, products.NewProductServer(log.StandardLogger(), repos))
+	pb.RegisterWebhooksServer(s, webhooks.NewWebhookServer(log.StandardLogger(), repos))
 	go func() {
 		if err := s.Serve(listener); err != nil {
 			log.Fatal(err)
 		}
 	}()
-	c := make(chan os.Signal)
+	metricsServer := serveMetrics(cfg.Metrics, appMetrics)
+	c := make(chan os.Signal, 1)
 
 	signal.Notify(c, os.Interrupt)
 	// Block main routine until a signal is received
 	<-c
 	log.Warning("Shutting down server")
+	stop()
 	s.Stop()
-	log.Println("Shutting down mongo")
-	closeErr := mongo.Client.Disconnect(mongo.Context)
+	if metricsServer != nil {
+		metricsServer.Close()
+	}
+	log.Println("Shutting down store")
+	closeErr := closeStore()
 	if closeErr != nil {
-		log.Warning("Mongo close connection failed ", closeErr)
+		log.Warning("Store close connection failed ", closeErr)
 	}
 	lisErr := listener.Close()
 	if lisErr != nil {
 		log.Println("Something happened", err)
 	}
 }
+
+// openStore opens the storage backend selected by store.driver. The memory
+// driver runs the whole server without Mongo or Redis; postgres and sqlite
+// use the SQL repositories with store.dsn as data source. Store operations
+// are reported to m: the methods of the document stores, the statements of
+// the SQL ones.
+func openStore(cfg *config.Config, m *metrics.Metrics) (*repository.Repositories, cache.ICache, func() error) {
+	switch cfg.Store.Driver {
+	case "memory":
+		log.Warning("Using the in-memory store, data is lost on shutdown")
+		store := db.NewMemoryStore()
+		ensureIndexes(store)
+		return repository.NewMongoRepositories(db.NewObservedStore(store, m.ObserveStore)), cache.NewMemoryCache(), store.Close
+	case "mongo":
+		store := db.NewMongoStore(cfg.Mongo)
+		ensureIndexes(store)
+		return repository.NewMongoRepositories(db.NewObservedStore(store, m.ObserveStore)), openRedis(cfg.Redis), store.Close
+	default:
+		driver := cfg.Store.Driver
+		conn, err := sql.Open(driver, cfg.Store.DSN)
+		if err != nil {
+			log.Fatalf("Failed to open %s database: %s", driver, err)
+		}
+		if driver == "sqlite" {
+			// SQLite allows a single writer; one connection also keeps
+			// in-memory databases from being opened once per connection.
+			conn.SetMaxOpenConns(1)
+		}
+		if err := repository.MigrateSQL(conn); err != nil {
+			log.Fatalf("Failed to migrate %s database: %s", driver, err)
+		}
+		return repository.NewObservedSQLRepositories(conn, m.ObserveStore), openRedis(cfg.Redis), conn.Close
+	}
+}
+
+// ensureIndexes fails when existing documents break a unique index, which
+// cmd/migrate repairs.
+func ensureIndexes(store db.Store) {
+	if err := repository.EnsureMongoIndexes(store); err != nil {
+		log.Fatalf("Failed to create indexes, run cmd/migrate first: %s", err)
+	}
+}
+
+// orderSequence picks the counter behind order numbers.
+func orderSequence(cfg *config.Config, repos *repository.Repositories, redisCache cache.ICache) orderno.Sequence {
+	if cfg.OrderNumbers.Sequence == "redis" {
+		return orderno.CacheSequence{Cache: redisCache}
+	}
+	return repos.Sequences
+}
+
+// eventSource picks where the order events come from. With the redis
+// fanout it also starts relaying the store's changes to the Redis stream;
+// Mongo change streams see every instance's writes, so one instance relays
+// for all, while the SQL drivers only see local writes and each relay them.
+func eventSource(ctx context.Context, cfg *config.Config, repos *repository.Repositories, redisCache cache.ICache) events.Source {
+	changes := events.NewChangeSource(log.StandardLogger(), repos.Orders)
+	if cfg.Streams.Fanout != "redis" {
+		return changes
+	}
+	streams, ok := redisCache.(cache.Streams)
+	if !ok {
+		log.Fatal("The redis stream fanout needs a cache with streams")
+	}
+	// Keep enough entries for the instances to resume reading after a
+	// hiccup, even when clients get little history.
+	maxLen := cfg.Streams.History
+	if maxLen < 1000 {
+		maxLen = 1000
+	}
+	relay := events.NewRelay(log.StandardLogger(), changes, streams, events.RelayOptions{
+		Key:       cfg.Streams.RedisKey,
+		MaxLen:    int64(maxLen),
+		Exclusive: cfg.Store.Driver == "mongo",
+	})
+	go relay.Run(ctx)
+	return events.NewRedisSource(log.StandardLogger(), streams, cfg.Streams.RedisKey)
+}
+
+// outboxPublisher picks where the outbox messages are published.
+func outboxPublisher(cfg *config.Config, redisCache cache.ICache) outbox.Publisher {
+	if cfg.Outbox.Publisher != "redis" {
+		return outbox.LogPublisher{Log: log.StandardLogger()}
+	}
+	streams, ok := redisCache.(cache.Streams)
+	if !ok {
+		log.Fatal("The redis outbox publisher needs a cache with streams")
+	}
+	return outbox.StreamPublisher{Streams: streams, Key: cfg.Outbox.RedisKey}
+}
+
+// serveMetrics serves the metrics at /metrics on their own port, so they
+// are not exposed with the API. It returns nil when no port is set.
+func serveMetrics(cfg config.Metrics, m *metrics.Metrics) *http.Server {
+	if cfg.Port == "" {
+		return nil
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", m.Handler())
+	server := &http.Server{Addr: ":" + cfg.Port, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("Failed to serve metrics on port %s: %s", cfg.Port, err)
+		}
+	}()
+	return server
+}
+
+func openRedis(cfg config.Redis) *cache.RedisCache {
+	redisCache, err := cache.InitRedisCache(cfg)
+	if err != nil {
+		log.Fatalf("Failed to connect to redis at %s: %s", cfg.Addr, err)
+	}
+	return redisCache
+}
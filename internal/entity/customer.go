@@ -1,39 +1,49 @@
 package entity
 
 import (
-	"awesomeProject/pkg/db"
 	"awesomeProject/pkg/utils"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
 )
 
 type Location struct {
-	Lat  float32 `bson:"lat" json:"lat" validate:"required"`
-	Long float32 `bson:"long" json:"long" validate:"required"`
+	Lat  float32 `bson:"lat" json:"lat" validate:"latitude"`
+	Long float32 `bson:"long" json:"long" validate:"longitude"`
 }
 
 type Customer struct {
-	ID        primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
-	Name      string              `bson:"name" json:"name" validate:"required"`
-	Position  Location            `bson:"position" json:"position" validate:"required"`
-	CreatedAt primitive.Timestamp `bson:"createdAt" json:"createdAt"`
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Name      string             `bson:"name" json:"name" validate:"required"`
+	Position  Location           `bson:"position" json:"position" validate:"required"`
+	CreatedAt time.Time          `bson:"createdAt" json:"createdAt"`
+	UpdatedAt time.Time          `bson:"updatedAt" json:"updatedAt"`
+}
+
+// CustomerStore is the storage a Customer persists itself through.
+type CustomerStore interface {
+	Insert(customer *Customer) error
+	Replace(customer *Customer) error
+	Get(filter utils.KeyValue, customer *Customer) error
 }
 
 func NewCustomer() *Customer {
 	return &Customer{}
 }
 
-func (c *Customer) GetCustomer(db *db.MongoStore, filter utils.KeyValue) (*Customer, error) {
-	err := db.Get(CustomerCollectionName, filter, c)
+func (c *Customer) GetCustomer(store CustomerStore, filter utils.KeyValue) (*Customer, error) {
+	err := store.Get(filter, c)
 	return c, err
 }
 
-func (c *Customer) Persist(db *db.MongoStore) (*Customer, error) {
+func (c *Customer) Persist(store CustomerStore) (*Customer, error) {
 	isNewCustomer := c.CreatedAt.IsZero()
 	var err error
+	c.UpdatedAt = now()
 	if isNewCustomer {
-		err = db.Insert(CustomerCollectionName, c)
+		c.CreatedAt = c.UpdatedAt
+		err = store.Insert(c)
 	} else {
-		err = db.Replace(CustomerCollectionName, utils.KeyValue{"_id": c.ID}, c)
+		err = store.Replace(c)
 	}
 
 	return c, err
@@ -0,0 +1,70 @@
+package entity
+
+import (
+	"awesomeProject/pkg/money"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+// OrderLine is a snapshot of a catalog product taken when it was ordered,
+// so later catalog changes never alter the order.
+type OrderLine struct {
+	ProductID   primitive.ObjectID `bson:"productId" json:"productId" validate:"required"`
+	Name        string             `bson:"name" json:"name" validate:"required"`
+	Description string             `bson:"description" json:"description"`
+	SKU         string             `bson:"sku" json:"sku" validate:"required"`
+	Quantity    int64              `bson:"quantity" json:"quantity" validate:"gt=0,lte=10000"`
+	UnitPrice   money.Money        `bson:"unitPrice" json:"unitPrice"`
+	LineTotal   money.Money        `bson:"lineTotal" json:"lineTotal"`
+	CreatedAt   time.Time          `bson:"createdAt" json:"createdAt"`
+}
+
+// Pricing holds the rules order totals are computed with.
+type Pricing struct {
+	Currency string
+	// TaxRate is in basis points of the subtotal, so 1950 is 19.5%.
+	TaxRate int64
+	// ShippingFee is charged in minor units unless the subtotal reaches
+	// FreeShippingFrom; a zero FreeShippingFrom never waives it.
+	ShippingFee      int64
+	FreeShippingFrom int64
+}
+
+// ComputeTotals recomputes the line totals and the subtotal, tax, shipping
+// and grand total of the order. Every line must be priced in the pricing
+// currency. Amounts too large for minor units fail with money.ErrOverflow.
+func (o *Order) ComputeTotals(pricing Pricing) error {
+	subtotal := money.Zero(pricing.Currency)
+	for i := range o.Items {
+		line := &o.Items[i]
+		var err error
+		if line.LineTotal, err = line.UnitPrice.Mul(line.Quantity); err != nil {
+			return err
+		}
+		if subtotal, err = subtotal.Add(line.LineTotal); err != nil {
+			return err
+		}
+	}
+
+	shipping := money.New(pricing.ShippingFee, pricing.Currency)
+	if pricing.FreeShippingFrom > 0 && subtotal.Amount >= pricing.FreeShippingFrom {
+		shipping = money.Zero(pricing.Currency)
+	}
+	tax, err := subtotal.Rate(pricing.TaxRate)
+	if err != nil {
+		return err
+	}
+	total, err := subtotal.Add(tax)
+	if err == nil {
+		total, err = total.Add(shipping)
+	}
+	if err != nil {
+		return err
+	}
+
+	o.Subtotal = subtotal
+	o.Tax = tax
+	o.Shipping = shipping
+	o.Total = total
+	return nil
+}
@@ -1,36 +1,113 @@
 package entity
 
 import (
-	"awesomeProject/pkg/db"
+	"awesomeProject/pkg/money"
 	"awesomeProject/pkg/utils"
-	"go.mongodb.org/mongo-driver/bson"
+	"errors"
+	"fmt"
 	"go.mongodb.org/mongo-driver/bson/primitive"
-	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
 )
 
 type OrderStatus string
 
 const (
-	processing OrderStatus = "processing"
-	transit                = "transit"
-	delivered              = "delivered"
-	cancelled              = "cancelled"
+	Processing OrderStatus = "processing"
+	Transit    OrderStatus = "transit"
+	Delivered  OrderStatus = "delivered"
+	Cancelled  OrderStatus = "cancelled"
 )
 
+// statusTransitions lists the statuses each status may move to. Statuses
+// without an entry are final.
+var statusTransitions = map[OrderStatus][]OrderStatus{
+	Processing: {Transit, Cancelled},
+	Transit:    {Delivered},
+}
+
+// ErrStatusChanged is returned when the order changed status between being
+// read and being updated.
+var ErrStatusChanged = errors.New("order status changed concurrently")
+
+// TransitionError rejects a status change the transition table forbids.
+type TransitionError struct {
+	From OrderStatus
+	To   OrderStatus
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("order cannot move from %s to %s", e.From, e.To)
+}
+
+func (s OrderStatus) IsValid() bool {
+	switch s {
+	case Processing, Transit, Delivered, Cancelled:
+		return true
+	}
+	return false
+}
+
+// CanTransitionTo reports whether the transition table allows moving to next.
+func (s OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	for _, allowed := range statusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// OpenStatuses lists the statuses an order can still leave.
+func OpenStatuses() []OrderStatus {
+	open := make([]OrderStatus, 0, len(statusTransitions))
+	for status := range statusTransitions {
+		open = append(open, status)
+	}
+	return open
+}
+
+// IsFinal reports whether no transition leaves this status.
+func (s OrderStatus) IsFinal() bool {
+	return len(statusTransitions[s]) == 0
+}
+
 type Order struct {
-	ID           primitive.ObjectID  `bson:"_id,omitempty" json:"id" mapstructure:"_id"`
-	Status       string              `bson:"status" json:"status" mapstructure:"status" validate:"required"`
-	OrderNo      string              `bson:"orderNo" json:"orderNo" mapstructure:"orderNo"`
-	Items        []Product           `bson:"items" json:"items" mapstructure:"items"`
-	CustomerId   primitive.ObjectID  `bson:"customerId" json:"customerId" mapstructure:"customerId"`
-	DeliveryDate primitive.Timestamp `bson:"deliveryDate" json:"deliveryDate" mapstructure:"deliveryDate" validate:"required"`
-	CreatedAt    primitive.Timestamp `bson:"createdAt" json:"createdAt" validate:"required" mapstructure:"createdAt"`
+	ID           primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Status       OrderStatus        `bson:"status" json:"status" validate:"required"`
+	OrderNo      string             `bson:"orderNo" json:"orderNo"`
+	Items        []OrderLine        `bson:"items" json:"lines" validate:"required,min=1,dive"`
+	CustomerId   primitive.ObjectID `bson:"customerId" json:"customerId" validate:"required"`
+	DeliveryDate time.Time          `bson:"deliveryDate" json:"deliveryDate" validate:"required,future"`
+	CreatedAt    time.Time          `bson:"createdAt" json:"createdAt"`
+	UpdatedAt    time.Time          `bson:"updatedAt" json:"updatedAt"`
+	Subtotal     money.Money        `bson:"subtotal" json:"subtotal"`
+	Tax          money.Money        `bson:"tax" json:"tax"`
+	Shipping     money.Money        `bson:"shipping" json:"shipping"`
+	Total        money.Money        `bson:"total" json:"total"`
 }
 
 type Orders []*Order
 
 const OrderCollectionName = "orders"
 
+// OrderStore is the storage an Order persists itself through.
+// It lives here rather than in the repository package so entities do not
+// depend on their repositories; repository.OrderRepository embeds it.
+type OrderStore interface {
+	Insert(order *Order) error
+	// Replace writes the order over the stored one as long as its status is
+	// still order.Status, and fails with ErrStatusChanged otherwise, or with
+	// db.ErrNotFound when the order is gone. The status itself only changes
+	// through UpdateStatus.
+	Replace(order *Order) error
+	Get(filter utils.KeyValue, order *Order) error
+	GetAll(filter utils.KeyValue) (Orders, error)
+	// UpdateStatus sets the status of the order with the given id only if
+	// it is still from, stamping it updated at the given time. It returns
+	// the number of orders matched.
+	UpdateStatus(id primitive.ObjectID, from OrderStatus, to OrderStatus, at time.Time) (int64, error)
+}
+
 func NewOrder() *Order {
 	return &Order{}
 }
@@ -39,43 +116,54 @@ func NewOrders() *Orders {
 	return &Orders{}
 }
 
-func (o *Order) Persist(db *db.MongoStore) (*Order, error) {
+// IsClosed reports whether the order reached a final status and can no
+// longer be changed.
+func (o *Order) IsClosed() bool {
+	return o.Status.IsFinal()
+}
+
+func (o *Order) Persist(store OrderStore) (*Order, error) {
 	isNewOrder := o.CreatedAt.IsZero()
 	var err error
 
+	o.UpdatedAt = now()
+	o.DeliveryDate = o.DeliveryDate.UTC().Truncate(time.Millisecond)
 	if isNewOrder {
-		err = db.Insert(OrderCollectionName, o)
+		// Stamping CreatedAt makes the next Persist replace the order.
+		o.CreatedAt = o.UpdatedAt
+		err = store.Insert(o)
 	} else {
-		err = db.Replace(OrderCollectionName, utils.KeyValue{
-			"_id": o.ID,
-		}, o)
+		err = store.Replace(o)
 	}
 
 	return o, err
 }
 
-func (o *Order) Get(db *db.MongoStore, filter utils.KeyValue) (*Order, error) {
-	err := db.Get(OrderCollectionName, filter, o)
+func (o *Order) Get(store OrderStore, filter utils.KeyValue) (*Order, error) {
+	err := store.Get(filter, o)
 	return o, err
 }
 
-func (o *Order) UpdateOne(db *db.MongoStore, id string, status string) (int, error) {
-	opts := options.Update().SetUpsert(true)
-	objID, idErr := primitive.ObjectIDFromHex(id)
-	if idErr != nil {
-		return -1, idErr
+// UpdateStatus moves a stored order to status. The change is refused with a
+// *TransitionError when the transition table forbids it, and with
+// ErrStatusChanged when another writer changed the status first.
+func (o *Order) UpdateStatus(store OrderStore, status OrderStatus) error {
+	if !o.Status.CanTransitionTo(status) {
+		return &TransitionError{From: o.Status, To: status}
 	}
-	filter := bson.M{"_id": bson.M{"$eq": objID}}
-	update := bson.M{"$set": bson.M{"status": status}}
-
-	result, err := db.UpdateOne(OrderCollectionName, filter, update, options.UpdateOptions{
-		Upsert: opts.Upsert,
-	})
-
-	return int(result.ModifiedCount), err
+	at := now()
+	matched, err := store.UpdateStatus(o.ID, o.Status, status, at)
+	if err != nil {
+		return err
+	}
+	if matched == 0 {
+		return ErrStatusChanged
+	}
+	o.Status = status
+	o.UpdatedAt = at
+	return nil
 }
 
-func (os Orders) GetAll(db *db.MongoStore, filter utils.KeyValue) (Orders, error) {
-	err := db.GetAll(OrderCollectionName, filter, os)
-	return os, err
+func (os Orders) GetAll(store OrderStore, filter utils.KeyValue) (Orders, error) {
+	return store.GetAll(filter)
 }
@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+const OutboxCollectionName = "outbox"
+
+// OutboxRecord is a message waiting to be published downstream. It is
+// written in the same transaction as the change it reports, so no change is
+// committed without its message. The id doubles as the deduplication id
+// consumers use, as a message can be delivered more than once.
+type OutboxRecord struct {
+	ID    primitive.ObjectID `bson:"_id" json:"id"`
+	Topic string             `bson:"topic" json:"topic"`
+	// Key groups the messages of one entity, such as an order id.
+	Key         string    `bson:"key" json:"key"`
+	Payload     string    `bson:"payload" json:"payload"`
+	CreatedAt   time.Time `bson:"createdAt" json:"createdAt"`
+	PublishedAt time.Time `bson:"publishedAt" json:"publishedAt"`
+	Attempts    int       `bson:"attempts" json:"attempts"`
+	LastError   string    `bson:"lastError" json:"lastError"`
+}
+
+func NewOutboxRecord(topic string, key string, payload string) *OutboxRecord {
+	return &OutboxRecord{
+		ID:        primitive.NewObjectID(),
+		Topic:     topic,
+		Key:       key,
+		Payload:   payload,
+		CreatedAt: now(),
+	}
+}
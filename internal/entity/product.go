@@ -1,40 +1,67 @@
 package entity
 
 import (
-	"awesomeProject/pkg/db"
+	"awesomeProject/pkg/money"
 	"awesomeProject/pkg/utils"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
 )
 
 type Product struct {
-	ID          primitive.ObjectID  `bson:"_id,omitempty" json:"_id" mapstructure:"_id"`
-	Name        string              `bson:"name" json:"name" mapstructure:"name"`
-	Description string              `bson:"description" json:"description" mapstructure:"description"`
-	Price       float64             `bson:"price" json:"price" mapstructure:"price"`
-	SKU         string              `bson:"sku" json:"sku" mapstructure:"sku"`
-	CreatedAt   primitive.Timestamp `bson:"createdAt" json:"createdAt" mapstructure:"createdAt"`
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"_id"`
+	Name        string             `bson:"name" json:"name" validate:"required"`
+	Description string             `bson:"description" json:"description"`
+	Price       money.Money        `bson:"price" json:"price"`
+	SKU         string             `bson:"sku" json:"sku" validate:"required"`
+	Archived    bool               `bson:"archived,omitempty" json:"archived,omitempty"`
+	CreatedAt   time.Time          `bson:"createdAt" json:"createdAt"`
+	UpdatedAt   time.Time          `bson:"updatedAt" json:"updatedAt"`
 }
 
+// ProductCollectionName holds the catalog. Orders keep their own copy of
+// each product in their items, so catalog changes never alter past orders.
 const ProductCollectionName = "products"
 
+// ProductStore is the storage a Product persists itself through.
+type ProductStore interface {
+	Insert(product *Product) error
+	Replace(product *Product) error
+	Get(filter utils.KeyValue, product *Product) error
+}
+
 func NewProduct() Product {
 	return Product{}
 }
 
-func (p *Product) GetProduct(db *db.MongoStore, filter utils.KeyValue) (*Product, error) {
-	err := db.Get(ProductCollectionName, filter, p)
+func (p *Product) GetProduct(store ProductStore, filter utils.KeyValue) (*Product, error) {
+	err := store.Get(filter, p)
 	return p, err
 }
 
-func (p *Product) Persist(db *db.MongoStore) (*Product, error) {
+// Line copies the product into a new order line for quantity units. The
+// line keeps the catalog id so orders can be traced back to the product;
+// its LineTotal is left to Order.ComputeTotals.
+func (p *Product) Line(quantity int64) OrderLine {
+	return OrderLine{
+		ProductID:   p.ID,
+		Name:        p.Name,
+		Description: p.Description,
+		SKU:         p.SKU,
+		Quantity:    quantity,
+		UnitPrice:   p.Price,
+		CreatedAt:   now(),
+	}
+}
+
+func (p *Product) Persist(store ProductStore) (*Product, error) {
 	isNew := p.CreatedAt.IsZero()
 	var err error
+	p.UpdatedAt = now()
 	if isNew {
-		err = db.Insert(ProductCollectionName, p)
+		p.CreatedAt = p.UpdatedAt
+		err = store.Insert(p)
 	} else {
-		err = db.Replace(ProductCollectionName, utils.KeyValue{
-			"_id": p.ID,
-		}, p)
+		err = store.Replace(p)
 	}
 
 	return p, err
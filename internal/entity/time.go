@@ -0,0 +1,10 @@
+package entity
+
+import "time"
+
+// now is the time entities are stamped with. It is cut to the millisecond
+// precision of BSON dates and the SQL columns so a stored entity reads back
+// unchanged.
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Millisecond)
+}
@@ -0,0 +1,165 @@
+package entity
+
+import (
+	"awesomeProject/pkg/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+// WebhookCollectionName holds the partner endpoints order events are
+// delivered to.
+const WebhookCollectionName = "webhooks"
+
+// WebhookDeliveryCollectionName holds one delivery per event and webhook.
+const WebhookDeliveryCollectionName = "webhookDeliveries"
+
+type Webhook struct {
+	ID  primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	URL string             `bson:"url" json:"url" validate:"required"`
+	// EventTypes are the order event types delivered, all when empty.
+	EventTypes []string `bson:"eventTypes" json:"eventTypes"`
+	// Secret keys the signatures of the deliveries.
+	Secret    string    `bson:"secret" json:"-" validate:"required"`
+	Disabled  bool      `bson:"disabled" json:"disabled"`
+	CreatedAt time.Time `bson:"createdAt" json:"createdAt"`
+	UpdatedAt time.Time `bson:"updatedAt" json:"updatedAt"`
+}
+
+// WebhookStore is the storage a Webhook persists itself through.
+type WebhookStore interface {
+	Insert(webhook *Webhook) error
+	Replace(webhook *Webhook) error
+	Get(filter utils.KeyValue, webhook *Webhook) error
+}
+
+func NewWebhook() *Webhook {
+	return &Webhook{}
+}
+
+func (w *Webhook) GetWebhook(store WebhookStore, filter utils.KeyValue) (*Webhook, error) {
+	err := store.Get(filter, w)
+	return w, err
+}
+
+// Subscribed reports whether events of eventType are delivered to the
+// webhook.
+func (w *Webhook) Subscribed(eventType string) bool {
+	if w.Disabled {
+		return false
+	}
+	if len(w.EventTypes) == 0 {
+		return true
+	}
+	for _, subscribed := range w.EventTypes {
+		if subscribed == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *Webhook) Persist(store WebhookStore) (*Webhook, error) {
+	isNew := w.CreatedAt.IsZero()
+	var err error
+	w.UpdatedAt = now()
+	if isNew {
+		w.CreatedAt = w.UpdatedAt
+		err = store.Insert(w)
+	} else {
+		err = store.Replace(w)
+	}
+
+	return w, err
+}
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliveryDelivered DeliveryStatus = "delivered"
+	// DeliveryDead deliveries ran out of attempts. They stay until they
+	// are replayed.
+	DeliveryDead DeliveryStatus = "dead"
+)
+
+// WebhookDelivery is one event on its way to one webhook.
+type WebhookDelivery struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	WebhookID primitive.ObjectID `bson:"webhookId" json:"webhookId"`
+	// EventID is sent with every attempt and replay, so receivers can drop
+	// the events they already processed.
+	EventID string `bson:"eventId" json:"eventId"`
+	// DedupKey is unique, so an event published twice is queued once per
+	// webhook.
+	DedupKey  string         `bson:"dedupKey" json:"-"`
+	EventType string         `bson:"eventType" json:"eventType"`
+	Payload   string         `bson:"payload" json:"payload"`
+	Status    DeliveryStatus `bson:"status" json:"status"`
+	Attempts  int            `bson:"attempts" json:"attempts"`
+	// NextAttemptAt is when a pending delivery is due.
+	NextAttemptAt time.Time `bson:"nextAttemptAt" json:"nextAttemptAt"`
+	LastError     string    `bson:"lastError" json:"lastError"`
+	// ResponseCode is the HTTP status of the last attempt, 0 when there
+	// was no response.
+	ResponseCode int       `bson:"responseCode" json:"responseCode"`
+	CreatedAt    time.Time `bson:"createdAt" json:"createdAt"`
+	UpdatedAt    time.Time `bson:"updatedAt" json:"updatedAt"`
+	DeliveredAt  time.Time `bson:"deliveredAt" json:"deliveredAt"`
+}
+
+// NewWebhookDelivery queues an event for a webhook, due right away.
+func NewWebhookDelivery(webhookID primitive.ObjectID, eventID string, eventType string, payload string) *WebhookDelivery {
+	at := now()
+	return &WebhookDelivery{
+		ID:            primitive.NewObjectID(),
+		WebhookID:     webhookID,
+		EventID:       eventID,
+		DedupKey:      webhookID.Hex() + ":" + eventID,
+		EventType:     eventType,
+		Payload:       payload,
+		Status:        DeliveryPending,
+		NextAttemptAt: at,
+		CreatedAt:     at,
+		UpdatedAt:     at,
+	}
+}
+
+// Delivered records a successful attempt.
+func (d *WebhookDelivery) Delivered(code int) {
+	d.Attempts++
+	d.Status = DeliveryDelivered
+	d.ResponseCode = code
+	d.LastError = ""
+	d.UpdatedAt = now()
+	d.DeliveredAt = d.UpdatedAt
+}
+
+// Failed records a failed attempt, due again at retryAt. A zero retryAt
+// makes the delivery a dead letter.
+func (d *WebhookDelivery) Failed(reason string, code int, retryAt time.Time) {
+	d.Attempts++
+	d.ResponseCode = code
+	d.LastError = reason
+	d.UpdatedAt = now()
+	if retryAt.IsZero() {
+		d.Status = DeliveryDead
+		return
+	}
+	d.NextAttemptAt = retryAt.UTC().Truncate(time.Millisecond)
+}
+
+// Abandon makes the delivery a dead letter without an attempt, when it
+// can no longer be delivered.
+func (d *WebhookDelivery) Abandon(reason string) {
+	d.Status = DeliveryDead
+	d.LastError = reason
+	d.UpdatedAt = now()
+}
+
+// Replay queues the delivery again with a fresh set of attempts.
+func (d *WebhookDelivery) Replay() {
+	d.Status = DeliveryPending
+	d.Attempts = 0
+	d.UpdatedAt = now()
+	d.NextAttemptAt = d.UpdatedAt
+}
@@ -0,0 +1,127 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Kind classifies a domain error independently of the transport.
+type Kind int
+
+const (
+	Internal Kind = iota
+	NotFound
+	InvalidArgument
+	Conflict
+	FailedPrecondition
+	Aborted
+	Unavailable
+	ResourceExhausted
+)
+
+func (k Kind) String() string {
+	switch k {
+	case NotFound:
+		return "not found"
+	case InvalidArgument:
+		return "invalid argument"
+	case Conflict:
+		return "conflict"
+	case FailedPrecondition:
+		return "failed precondition"
+	case Aborted:
+		return "aborted"
+	case Unavailable:
+		return "unavailable"
+	case ResourceExhausted:
+		return "resource exhausted"
+	}
+	return "internal"
+}
+
+// FieldViolation describes why one request field is invalid.
+type FieldViolation struct {
+	Field       string
+	Description string
+}
+
+// Error is the error handlers return. Message is safe to show to clients;
+// Err is the underlying cause and is only logged.
+type Error struct {
+	Kind       Kind
+	Message    string
+	Violations []FieldViolation
+	Err        error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %s: %s", e.Kind, e.Message, e.Err)
+	}
+	return fmt.Sprintf("%s: %s", e.Kind, e.Message)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func newError(kind Kind, format string, args ...interface{}) *Error {
+	return &Error{Kind: kind, Message: fmt.Sprintf(format, args...)}
+}
+
+func NewNotFound(format string, args ...interface{}) *Error {
+	return newError(NotFound, format, args...)
+}
+
+// NewInvalidArgument reports a bad request, optionally per field.
+func NewInvalidArgument(message string, violations ...FieldViolation) *Error {
+	return &Error{Kind: InvalidArgument, Message: message, Violations: violations}
+}
+
+// NewFieldViolation is shorthand for an invalid argument on a single field.
+func NewFieldViolation(field string, format string, args ...interface{}) *Error {
+	description := fmt.Sprintf(format, args...)
+	return NewInvalidArgument(field+": "+description, FieldViolation{Field: field, Description: description})
+}
+
+func NewConflict(format string, args ...interface{}) *Error {
+	return newError(Conflict, format, args...)
+}
+
+func NewFailedPrecondition(format string, args ...interface{}) *Error {
+	return newError(FailedPrecondition, format, args...)
+}
+
+func NewAborted(format string, args ...interface{}) *Error {
+	return newError(Aborted, format, args...)
+}
+
+func NewUnavailable(err error, format string, args ...interface{}) *Error {
+	e := newError(Unavailable, format, args...)
+	e.Err = err
+	return e
+}
+
+func NewResourceExhausted(format string, args ...interface{}) *Error {
+	return newError(ResourceExhausted, format, args...)
+}
+
+// Wrap attaches a client safe message to an unexpected error.
+func Wrap(err error, format string, args ...interface{}) *Error {
+	if err == nil {
+		return nil
+	}
+	e := newError(Internal, format, args...)
+	e.Err = err
+	return e
+}
+
+// KindOf returns the kind of the first *Error in err's chain, Internal when
+// there is none.
+func KindOf(err error) Kind {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Kind
+	}
+	return Internal
+}
@@ -0,0 +1,95 @@
+package errs
+
+import (
+	"awesomeProject/internal/entity"
+	"awesomeProject/pkg/db"
+	"context"
+	"errors"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var grpcCodes = map[Kind]codes.Code{
+	Internal:           codes.Internal,
+	NotFound:           codes.NotFound,
+	InvalidArgument:    codes.InvalidArgument,
+	Conflict:           codes.AlreadyExists,
+	FailedPrecondition: codes.FailedPrecondition,
+	Aborted:            codes.Aborted,
+	Unavailable:        codes.Unavailable,
+	ResourceExhausted:  codes.ResourceExhausted,
+}
+
+// ToStatus translates any error a handler returns into a gRPC status error.
+// Domain errors keep their message and field violations; storage errors are
+// classified; anything else becomes INTERNAL without leaking its text.
+func ToStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	e := classify(err)
+	if e.Kind == Internal || e.Kind == Unavailable {
+		logrus.WithError(err).Error(e.Message)
+	}
+
+	st := status.New(grpcCodes[e.Kind], e.Message)
+	if len(e.Violations) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, v := range e.Violations {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       v.Field,
+				Description: v.Description,
+			})
+		}
+		if detailed, detailErr := st.WithDetails(badRequest); detailErr == nil {
+			st = detailed
+		}
+	}
+	return st.Err()
+}
+
+// classify finds the domain error behind err, mapping the sentinel errors of
+// the storage layer on the way.
+func classify(err error) *Error {
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+	var transitionErr *entity.TransitionError
+	if errors.As(err, &transitionErr) {
+		return NewFailedPrecondition(transitionErr.Error())
+	}
+
+	switch {
+	case errors.Is(err, entity.ErrStatusChanged):
+		return NewAborted("the order changed status concurrently, retry")
+	case errors.Is(err, db.ErrNotFound):
+		return NewNotFound("not found")
+	case errors.Is(err, db.ErrDuplicateKey):
+		return NewConflict("already exists")
+	case errors.Is(err, db.ErrInvalidPageToken):
+		return NewFieldViolation("pageToken", "is invalid or belongs to a different query")
+	case errors.Is(err, context.DeadlineExceeded), mongo.IsTimeout(err), mongo.IsNetworkError(err):
+		return NewUnavailable(err, "storage is unavailable, retry later")
+	}
+	return Wrap(err, "internal error")
+}
+
+// UnaryServerInterceptor applies ToStatus to every unary handler.
+func UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	resp, err := handler(ctx, req)
+	return resp, ToStatus(err)
+}
+
+// StreamServerInterceptor applies ToStatus to every streaming handler.
+func StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return ToStatus(handler(srv, ss))
+}
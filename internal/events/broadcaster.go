@@ -0,0 +1,333 @@
+// Package events fans order changes out to the stream subscribers of this
+// server from a single source: the change stream of the orders, or a Redis
+// stream that one instance relays the changes to for all instances.
+//
+// Every event carries a resume token, the change stream _id of the change
+// or the id of the Redis stream entry. The broadcaster keeps the latest
+// events so that a subscriber can pick up after the last token it saw, and
+// reopens its source after the last event it read.
+package events
+
+import (
+	"awesomeProject/internal/entity"
+	"awesomeProject/pkg/db"
+	"context"
+	"errors"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"sync"
+	"time"
+)
+
+// Policy decides what happens to a subscriber whose buffer is full.
+type Policy string
+
+const (
+	// DropEvents skips the events a full subscriber has no room for.
+	DropEvents Policy = "drop"
+	// Disconnect ends the subscription of a full subscriber, so the client
+	// notices and can reconnect.
+	Disconnect Policy = "disconnect"
+)
+
+var (
+	// ErrSlowConsumer ends subscriptions closed by the Disconnect policy.
+	ErrSlowConsumer = errors.New("events: subscriber fell behind")
+	// ErrStopped ends the subscriptions still open when Run returns.
+	ErrStopped = errors.New("events: broadcaster stopped")
+	// ErrMissedEvents ends all subscriptions when the change stream could
+	// not be resumed and changes may have been lost.
+	ErrMissedEvents = errors.New("events: order changes were missed")
+	// ErrTokenExpired is returned by Subscribe for resume tokens that are
+	// no longer in the history.
+	ErrTokenExpired = errors.New("events: resume token expired")
+)
+
+// Type is the kind of change an Event reports.
+type Type string
+
+const (
+	Created       Type = "created"
+	Updated       Type = "updated"
+	StatusChanged Type = "status_changed"
+	Deleted       Type = "deleted"
+)
+
+// Event is one change to an order.
+type Event struct {
+	// Token resumes a subscription after this event. It is opaque to
+	// clients.
+	Token   string             `json:"-"`
+	Type    Type               `json:"type"`
+	OrderID primitive.ObjectID `json:"orderId"`
+	// Order is the order after the change, nil for deletes. It is shared
+	// by all subscribers and must not be modified.
+	Order *entity.Order `json:"order,omitempty"`
+	// Status is the status after the change, empty for deletes.
+	Status entity.OrderStatus `json:"status,omitempty"`
+	// PreviousStatus is set for StatusChanged events, and for Deleted
+	// events to the last status seen in the history.
+	PreviousStatus entity.OrderStatus `json:"previousStatus,omitempty"`
+	// CustomerID is zero for deletes of orders no longer in the history.
+	CustomerID primitive.ObjectID `json:"customerId"`
+}
+
+// Source produces the events a Broadcaster publishes.
+type Source interface {
+	// Stream hands emit the events after the one with token after, or
+	// those from now on when after is empty, until ctx is done or emit or
+	// the source fails. It fails with db.ErrHistoryLost when it cannot
+	// resume after the token.
+	Stream(ctx context.Context, after string, emit func(Event) error) error
+}
+
+type Options struct {
+	// Buffer is the number of events a subscriber can fall behind by.
+	Buffer int
+	Policy Policy
+	// Retry is the pause before a failed source is reopened.
+	Retry time.Duration
+	// History is the number of recent events kept for resuming.
+	History int
+}
+
+// Stats are the counters of a Broadcaster since it was created.
+type Stats struct {
+	Subscribers  int
+	Published    uint64
+	Dropped      uint64
+	Disconnected uint64
+}
+
+// Broadcaster reads the order changes once and hands every change to all
+// subscribers. Subscribers each get a bounded channel, so one slow client
+// cannot hold up the others or grow the server's memory.
+type Broadcaster struct {
+	log    *logrus.Logger
+	source Source
+	opts   Options
+
+	// position is the token of the last event read. Only Run uses it.
+	position string
+
+	mu      sync.Mutex
+	subs    map[*Subscription]struct{}
+	history []Event
+	stats   Stats
+}
+
+func NewBroadcaster(log *logrus.Logger, source Source, opts Options) *Broadcaster {
+	if opts.Buffer <= 0 {
+		opts.Buffer = 1
+	}
+	if opts.Policy == "" {
+		opts.Policy = DropEvents
+	}
+	if opts.Retry <= 0 {
+		opts.Retry = time.Second
+	}
+	if opts.History < 0 {
+		opts.History = 0
+	}
+	return &Broadcaster{
+		log:    log,
+		source: source,
+		opts:   opts,
+		subs:   map[*Subscription]struct{}{},
+	}
+}
+
+// Run reads the source until ctx is done, reopening it after errors where
+// it left off. The subscriptions still open when it returns are closed with
+// ErrStopped.
+func (b *Broadcaster) Run(ctx context.Context) {
+	defer b.closeAll(ErrStopped)
+	for {
+		err := b.source.Stream(ctx, b.position, b.emit)
+		if ctx.Err() != nil {
+			return
+		}
+		if errors.Is(err, db.ErrHistoryLost) {
+			b.log.WithError(err).Warning("Order events cannot resume, restarting from now")
+			b.forget()
+			continue
+		}
+		b.log.WithError(err).Warningf("Order events failed, reopening in %s", b.opts.Retry)
+		select {
+		case <-time.After(b.opts.Retry):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// emit publishes an event of the source and remembers it as the point to
+// resume the source from.
+func (b *Broadcaster) emit(event Event) error {
+	b.position = event.Token
+	b.publish(event)
+	return nil
+}
+
+func (b *Broadcaster) publish(event Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.stats.Published++
+	if event.Type == Deleted {
+		if last := b.last(event.OrderID); last != nil {
+			event.PreviousStatus = last.Status
+			event.CustomerID = last.CustomerID
+		}
+	}
+	if b.opts.History > 0 {
+		b.history = append(b.history, event)
+		if len(b.history) > b.opts.History {
+			b.history = b.history[len(b.history)-b.opts.History:]
+		}
+	}
+	for sub := range b.subs {
+		if !sub.filter.Match(event) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+			continue
+		default:
+		}
+		if b.opts.Policy == Disconnect {
+			b.stats.Disconnected++
+			b.remove(sub, ErrSlowConsumer)
+			b.log.Warningf("Disconnected a slow order stream subscriber, %d left", len(b.subs))
+			continue
+		}
+		b.stats.Dropped++
+	}
+}
+
+// forget drops the history and the resume point after changes were missed,
+// so that no subscriber resumes across the gap.
+func (b *Broadcaster) forget() {
+	b.position = ""
+	b.mu.Lock()
+	b.history = nil
+	b.mu.Unlock()
+	b.closeAll(ErrMissedEvents)
+}
+
+// Subscribe starts receiving the changes matching filter published after
+// the event with token after, or from now on when after is empty. It fails
+// with ErrTokenExpired when the event is no longer in the history. Callers
+// must Close the subscription when done.
+func (b *Broadcaster) Subscribe(after string, filter Filter) (*Subscription, error) {
+	b.mu.Lock()
+	var backlog []Event
+	if after != "" {
+		i := b.find(after)
+		if i < 0 {
+			b.mu.Unlock()
+			return nil, ErrTokenExpired
+		}
+		for _, event := range b.history[i+1:] {
+			if filter.Match(event) {
+				backlog = append(backlog, event)
+			}
+		}
+	}
+	sub := &Subscription{
+		broadcaster: b,
+		filter:      filter,
+		events:      make(chan Event, b.opts.Buffer+len(backlog)),
+	}
+	for _, event := range backlog {
+		sub.events <- event
+	}
+	b.subs[sub] = struct{}{}
+	b.stats.Subscribers = len(b.subs)
+	count := b.stats.Subscribers
+	b.mu.Unlock()
+	b.log.Infof("Order stream subscriber joined, %d subscribed", count)
+	return sub, nil
+}
+
+// find returns the index of the event with token in the history, or -1.
+// Callers must hold the lock.
+func (b *Broadcaster) find(token string) int {
+	for i := len(b.history) - 1; i >= 0; i-- {
+		if b.history[i].Token == token {
+			return i
+		}
+	}
+	return -1
+}
+
+// last returns the latest event in the history that carries the state of
+// an order, or nil. Callers must hold the lock.
+func (b *Broadcaster) last(id primitive.ObjectID) *Event {
+	for i := len(b.history) - 1; i >= 0; i-- {
+		if b.history[i].OrderID == id && b.history[i].Status != "" {
+			return &b.history[i]
+		}
+	}
+	return nil
+}
+
+// Stats returns a snapshot of the counters.
+func (b *Broadcaster) Stats() Stats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.stats
+}
+
+// remove closes a subscription for reason, reporting whether it was still
+// open. Callers must hold the lock.
+func (b *Broadcaster) remove(sub *Subscription, reason error) bool {
+	if _, ok := b.subs[sub]; !ok {
+		return false
+	}
+	delete(b.subs, sub)
+	b.stats.Subscribers = len(b.subs)
+	sub.err = reason
+	close(sub.events)
+	return true
+}
+
+func (b *Broadcaster) closeAll(reason error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for sub := range b.subs {
+		b.remove(sub, reason)
+	}
+}
+
+// Subscription is one subscriber's view of the broadcast.
+type Subscription struct {
+	broadcaster *Broadcaster
+	filter      Filter
+	events      chan Event
+	err         error
+}
+
+// Events delivers the changes. It is closed when the subscription ends;
+// Err then tells why.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Err is ErrSlowConsumer, ErrMissedEvents or ErrStopped once Events is
+// closed by the broadcaster, and nil while it is open or after Close.
+func (s *Subscription) Err() error {
+	s.broadcaster.mu.Lock()
+	defer s.broadcaster.mu.Unlock()
+	return s.err
+}
+
+func (s *Subscription) Close() {
+	b := s.broadcaster
+	b.mu.Lock()
+	removed := b.remove(s, nil)
+	left := len(b.subs)
+	b.mu.Unlock()
+	if removed {
+		b.log.Infof("Order stream subscriber left, %d subscribed", left)
+	}
+}
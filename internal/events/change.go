@@ -0,0 +1,107 @@
+package events
+
+import (
+	"awesomeProject/internal/entity"
+	"awesomeProject/internal/repository"
+	"awesomeProject/pkg/db"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+// orderChange is the part of a change stream event on the orders the
+// broadcaster reads.
+type orderChange struct {
+	ID            bson.Raw `bson:"_id"`
+	OperationType string   `bson:"operationType"`
+	DocumentKey   struct {
+		ID primitive.ObjectID `bson:"_id"`
+	} `bson:"documentKey"`
+	FullDocument      *entity.Order `bson:"fullDocument"`
+	UpdateDescription struct {
+		UpdatedFields struct {
+			Status entity.OrderStatus `bson:"status"`
+			// PreviousStatus is written by UpdateStatus alongside status.
+			PreviousStatus entity.OrderStatus `bson:"previousStatus"`
+		} `bson:"updatedFields"`
+	} `bson:"updateDescription"`
+}
+
+// event classifies the change. Updates that set the status are status
+// changes; the status comes from the update itself, as the full document is
+// looked up later and may already reflect newer changes.
+func (c orderChange) event(token string) Event {
+	event := Event{Token: token, OrderID: c.DocumentKey.ID, Order: c.FullDocument}
+	if c.FullDocument != nil {
+		event.Status = c.FullDocument.Status
+		event.CustomerID = c.FullDocument.CustomerId
+	}
+	updated := c.UpdateDescription.UpdatedFields
+	switch {
+	case c.OperationType == "insert":
+		event.Type = Created
+	case c.OperationType == "delete":
+		event.Type = Deleted
+	case c.OperationType == "update" && updated.Status != "":
+		event.Type = StatusChanged
+		event.Status = updated.Status
+		event.PreviousStatus = updated.PreviousStatus
+	default:
+		event.Type = Updated
+	}
+	return event
+}
+
+// watchWait is how long the change stream waits for changes per round trip.
+const watchWait = 24 * time.Hour
+
+// ChangeSource reads the events from the change stream of the orders. Its
+// tokens are the change stream _ids, base64 encoded.
+type ChangeSource struct {
+	log    *logrus.Logger
+	orders repository.OrderRepository
+}
+
+func NewChangeSource(log *logrus.Logger, orders repository.OrderRepository) *ChangeSource {
+	return &ChangeSource{log: log, orders: orders}
+}
+
+func (s *ChangeSource) Stream(ctx context.Context, after string, emit func(Event) error) error {
+	var resumeAfter bson.Raw
+	if after != "" {
+		raw, err := base64.RawURLEncoding.DecodeString(after)
+		if err != nil {
+			return fmt.Errorf("%w: %v", db.ErrHistoryLost, err)
+		}
+		resumeAfter = raw
+	}
+	stream, err := s.orders.Watch(watchWait, resumeAfter)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := stream.Close(context.Background()); err != nil {
+			s.log.WithError(err).Warning("Failed to close order change stream")
+		}
+	}()
+
+	for stream.Next(ctx) {
+		var change orderChange
+		if err := stream.Decode(&change); err != nil {
+			s.log.WithError(err).Error("Skipping undecodable order change")
+			continue
+		}
+		if err := emit(change.event(base64.RawURLEncoding.EncodeToString(change.ID))); err != nil {
+			return err
+		}
+	}
+	if err := stream.Err(); err != nil {
+		return err
+	}
+	return errors.New("events: change stream closed")
+}
@@ -0,0 +1,46 @@
+package events
+
+import (
+	"awesomeProject/internal/entity"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Filter selects the events a subscriber receives. Empty fields match
+// every event.
+type Filter struct {
+	CustomerID primitive.ObjectID
+	// Statuses match the status before or after the change.
+	Statuses []entity.OrderStatus
+	OrderIDs []primitive.ObjectID
+}
+
+// Match reports whether event passes the filter. Events without a customer
+// or status, such as deletes of orders no longer in the history, pass those
+// conditions so that subscribers can still drop the order.
+func (f Filter) Match(event Event) bool {
+	if !f.CustomerID.IsZero() && !event.CustomerID.IsZero() && event.CustomerID != f.CustomerID {
+		return false
+	}
+	if len(f.Statuses) > 0 && (event.Status != "" || event.PreviousStatus != "") &&
+		!containsStatus(f.Statuses, event.Status) && !containsStatus(f.Statuses, event.PreviousStatus) {
+		return false
+	}
+	if len(f.OrderIDs) > 0 {
+		for _, id := range f.OrderIDs {
+			if id == event.OrderID {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func containsStatus(statuses []entity.OrderStatus, status entity.OrderStatus) bool {
+	for _, candidate := range statuses {
+		if candidate == status && status != "" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,258 @@
+package events
+
+import (
+	"awesomeProject/pkg/cache"
+	"awesomeProject/pkg/db"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+// The entries of the Redis stream hold the event as JSON in the event
+// field. Entries written by a Relay also hold the token of the change in the
+// change field, and an entry with a lost field marks changes the relay
+// could not resume. Other producers can add events by writing entries with
+// just the event field.
+const (
+	eventField  = "event"
+	changeField = "change"
+	lostField   = "lost"
+)
+
+const (
+	// readBlock is how long a read waits for new entries before ctx is
+	// checked again.
+	readBlock = 5 * time.Second
+	readCount = 100
+	// relayLockTTL is how long the relay lock outlives a relay that stopped
+	// renewing it.
+	relayLockTTL = 10 * time.Second
+	// resumeScan is the number of latest entries searched for the change
+	// a relay resumes after.
+	resumeScan = 100
+)
+
+// RedisSource reads the events relayed to a Redis stream. Its tokens are
+// the stream entry ids, which are the same on every instance, so a client
+// can resume on any of them.
+type RedisSource struct {
+	log     *logrus.Logger
+	streams cache.Streams
+	key     string
+}
+
+func NewRedisSource(log *logrus.Logger, streams cache.Streams, key string) *RedisSource {
+	return &RedisSource{log: log, streams: streams, key: key}
+}
+
+func (s *RedisSource) Stream(ctx context.Context, after string, emit func(Event) error) error {
+	if after == "" {
+		latest, err := s.streams.XRevRange(s.key, "+", "-", 1)
+		if err != nil {
+			return err
+		}
+		after = "0-0"
+		if len(latest) > 0 {
+			after = latest[0].ID
+		}
+	} else {
+		// Entries after a trimmed one may be gone as well.
+		found, err := s.streams.XRevRange(s.key, after, after, 1)
+		if err != nil {
+			return err
+		}
+		if len(found) == 0 {
+			return fmt.Errorf("%w: entry %s was trimmed", db.ErrHistoryLost, after)
+		}
+	}
+
+	for ctx.Err() == nil {
+		messages, err := s.streams.XRead(ctx, s.key, after, readCount, readBlock)
+		if err != nil {
+			return err
+		}
+		for _, message := range messages {
+			after = message.ID
+			if _, ok := message.Values[lostField]; ok {
+				return fmt.Errorf("%w: relay restarted at %s", db.ErrHistoryLost, message.ID)
+			}
+			var event Event
+			if err := json.Unmarshal([]byte(message.Values[eventField]), &event); err != nil {
+				s.log.WithError(err).Errorf("Skipping undecodable order event %s", message.ID)
+				continue
+			}
+			event.Token = message.ID
+			if err := emit(event); err != nil {
+				return err
+			}
+		}
+	}
+	return ctx.Err()
+}
+
+type RelayOptions struct {
+	// Key is the Redis stream the events are written to.
+	Key string
+	// MaxLen trims the stream to about this many entries, 0 for no limit.
+	MaxLen int64
+	// Exclusive lets only the instance holding the relay lock copy the
+	// events. Sources that see the changes of every instance, like a
+	// Mongo change stream, need it; sources that see only the local
+	// writes must run on every instance instead.
+	Exclusive bool
+	// Retry is the pause before a failed relay starts again.
+	Retry time.Duration
+}
+
+// Relay copies the events of a source to a Redis stream that every
+// instance reads with a RedisSource, so the orders are watched once for the
+// whole deployment. Delivery is at least once: after a crash or a handover
+// of the lock, events may be written twice.
+type Relay struct {
+	log     *logrus.Logger
+	source  Source
+	streams cache.Streams
+	opts    RelayOptions
+	owner   string
+}
+
+func NewRelay(log *logrus.Logger, source Source, streams cache.Streams, opts RelayOptions) *Relay {
+	if opts.Retry <= 0 {
+		opts.Retry = time.Second
+	}
+	return &Relay{
+		log:     log,
+		source:  source,
+		streams: streams,
+		opts:    opts,
+		owner:   primitive.NewObjectID().Hex(),
+	}
+}
+
+// Run relays until ctx is done. An exclusive relay first waits for the
+// lock and stands by while another instance holds it.
+func (r *Relay) Run(ctx context.Context) {
+	for {
+		err := r.relayWhenLocked(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			r.log.WithError(err).Warningf("Order event relay failed, retrying in %s", r.opts.Retry)
+		}
+		wait := r.opts.Retry
+		if err == nil {
+			wait = relayLockTTL / 3
+		}
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// relayWhenLocked relays while this instance holds the lock, returning nil
+// right away when another one holds it.
+func (r *Relay) relayWhenLocked(ctx context.Context) error {
+	if !r.opts.Exclusive {
+		return r.relay(ctx, "")
+	}
+	held, err := r.streams.Lock(r.lockKey(), r.owner, relayLockTTL)
+	if err != nil || !held {
+		return err
+	}
+	r.log.Infof("Relaying order events to %s", r.opts.Key)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go r.keepLock(ctx, cancel)
+
+	after, err := r.resumePoint()
+	if err != nil {
+		return err
+	}
+	err = r.relay(ctx, after)
+	if ctx.Err() != nil {
+		return errors.New("events: relay lock lost")
+	}
+	return err
+}
+
+// relay copies events until ctx is done or the source fails. Changes the
+// source cannot resume after are marked in the stream, so that readers
+// know they missed some.
+func (r *Relay) relay(ctx context.Context, after string) error {
+	for {
+		err := r.source.Stream(ctx, after, r.append)
+		if !errors.Is(err, db.ErrHistoryLost) || ctx.Err() != nil {
+			return err
+		}
+		r.log.WithError(err).Warning("Order event relay cannot resume, restarting from now")
+		if _, err := r.streams.XAdd(r.opts.Key, r.opts.MaxLen, map[string]string{lostField: "1"}); err != nil {
+			return err
+		}
+		after = ""
+	}
+}
+
+func (r *Relay) append(event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = r.streams.XAdd(r.opts.Key, r.opts.MaxLen, map[string]string{
+		eventField:  string(data),
+		changeField: event.Token,
+	})
+	return err
+}
+
+// resumePoint is the token of the last change a relay wrote, empty when
+// there is none among the latest entries or a lost marker comes first.
+func (r *Relay) resumePoint() (string, error) {
+	latest, err := r.streams.XRevRange(r.opts.Key, "+", "-", resumeScan)
+	if err != nil {
+		return "", err
+	}
+	for _, message := range latest {
+		if _, ok := message.Values[lostField]; ok {
+			return "", nil
+		}
+		if change := message.Values[changeField]; change != "" {
+			return change, nil
+		}
+	}
+	return "", nil
+}
+
+// keepLock renews the relay lock until ctx is done, cancelling the relay
+// when the lock is lost.
+func (r *Relay) keepLock(ctx context.Context, cancel context.CancelFunc) {
+	ticker := time.NewTicker(relayLockTTL / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		held, err := r.streams.Lock(r.lockKey(), r.owner, relayLockTTL)
+		if err != nil {
+			r.log.WithError(err).Warning("Failed to renew the order event relay lock")
+			continue
+		}
+		if !held {
+			r.log.Warning("Order event relay lock taken over by another instance")
+			cancel()
+			return
+		}
+	}
+}
+
+func (r *Relay) lockKey() string {
+	return r.opts.Key + ":relay"
+}
@@ -0,0 +1,178 @@
+// Package idempotency lets clients retry creating requests safely. A
+// client sends a key of its choosing in the idempotency-key metadata; the
+// first request with the key runs, and retries with the same key get the
+// stored response instead of running again until the window is over. Keys
+// are kept per caller, so clients that happen to choose the same key do
+// not see each other's responses.
+package idempotency
+
+import (
+	"awesomeProject/internal/errs"
+	"awesomeProject/pkg/cache"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/proto"
+	"net"
+	"time"
+)
+
+const (
+	// KeyHeader is the metadata the client sends the key in.
+	KeyHeader = "idempotency-key"
+	// ReplayedHeader is set on the response metadata of a stored response.
+	ReplayedHeader = "idempotency-replayed"
+	maxKeyLength   = 255
+	// runningTTL bounds how long a key stays reserved by a request that
+	// died before storing its response.
+	runningTTL = time.Minute
+)
+
+// record is what is stored under a key: the fingerprint of the request,
+// and its response once it finished.
+type record struct {
+	Fingerprint string `json:"fingerprint"`
+	Done        bool   `json:"done"`
+	Response    []byte `json:"response,omitempty"`
+}
+
+// Store keeps the responses in a cache, Redis in production.
+type Store struct {
+	log    *logrus.Logger
+	cache  cache.ICache
+	window time.Duration
+}
+
+// NewStore keeps responses for window.
+func NewStore(log *logrus.Logger, cache cache.ICache, window time.Duration) *Store {
+	return &Store{log: log, cache: cache, window: window}
+}
+
+// Do runs handle for requests without a key, and once per key otherwise.
+// Failed requests are not stored, so a retry runs them again. A key reused
+// with a different request is refused, as is one whose first request is
+// still running. A nil store just runs handle.
+func Do[Res proto.Message](ctx context.Context, s *Store, method string, req proto.Message, handle func() (Res, error)) (Res, error) {
+	var none Res
+	if s == nil {
+		return handle()
+	}
+	key, err := requestKey(ctx)
+	if err != nil {
+		return none, err
+	}
+	if key == "" {
+		return handle()
+	}
+	fingerprint, err := fingerprintOf(req)
+	if err != nil {
+		return none, errs.Wrap(err, "failed to fingerprint request")
+	}
+	cacheKey := fmt.Sprintf("idempotency-%s-%s-%s", method, callerOf(ctx), key)
+
+	reserved, err := s.cache.SetNX(cacheKey, record{Fingerprint: fingerprint}, runningTTL)
+	if err != nil {
+		return none, errs.NewUnavailable(err, "failed to check the idempotency key")
+	}
+	if !reserved {
+		return stored[Res](ctx, s, cacheKey, key, fingerprint)
+	}
+
+	res, err := handle()
+	if err != nil {
+		if delErr := s.cache.Delete(cacheKey); delErr != nil {
+			s.log.WithError(delErr).Warningf("Failed to release idempotency key %s", key)
+		}
+		return res, err
+	}
+	b, err := proto.Marshal(res)
+	if err == nil {
+		err = s.cache.Set(cacheKey, record{Fingerprint: fingerprint, Done: true, Response: b}, s.window)
+	}
+	if err != nil {
+		// The request succeeded; a retry would run it again once the
+		// reservation runs out.
+		s.log.WithError(err).Errorf("Failed to store the response of idempotency key %s", key)
+	}
+	return res, nil
+}
+
+// stored answers a request whose key is taken.
+func stored[Res proto.Message](ctx context.Context, s *Store, cacheKey string, key string, fingerprint string) (Res, error) {
+	var none Res
+	raw, err := s.cache.Get(cacheKey)
+	if err != nil {
+		return none, errs.NewUnavailable(err, "failed to check the idempotency key")
+	}
+	var taken record
+	if raw == nil || json.Unmarshal(raw, &taken) != nil {
+		// Expired or unreadable since SetNX; the client can retry.
+		return none, errs.NewAborted("idempotency key %s changed during the request, retry", key)
+	}
+	if taken.Fingerprint != fingerprint {
+		return none, errs.NewFailedPrecondition("idempotency key %s was used for a different request", key)
+	}
+	if !taken.Done {
+		return none, errs.NewAborted("a request with idempotency key %s is still running, retry later", key)
+	}
+
+	res := none.ProtoReflect().New().Interface().(Res)
+	if err := proto.Unmarshal(taken.Response, res); err != nil {
+		return none, errs.Wrap(err, "failed to decode the stored response")
+	}
+	if err := grpc.SetHeader(ctx, metadata.Pairs(ReplayedHeader, "true")); err != nil {
+		s.log.WithError(err).Debug("Failed to mark a replayed response")
+	}
+	return res, nil
+}
+
+// requestKey returns the key sent with the request, empty when there is
+// none.
+func requestKey(ctx context.Context) (string, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(KeyHeader)
+	if len(values) == 0 {
+		return "", nil
+	}
+	key := values[0]
+	if key == "" || len(key) > maxKeyLength {
+		return "", errs.NewInvalidArgument(fmt.Sprintf("%s must be 1 to %d characters", KeyHeader, maxKeyLength))
+	}
+	return key, nil
+}
+
+// callerOf identifies who sent a request: the hashed credentials of the
+// authorization metadata when there are any, else the host the request
+// came from.
+func callerOf(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get("authorization"); len(values) > 0 && values[0] != "" {
+		sum := sha256.Sum256([]byte(values[0]))
+		return "auth:" + hex.EncodeToString(sum[:])
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		// The port changes with every connection a client opens.
+		host, _, err := net.SplitHostPort(p.Addr.String())
+		if err != nil {
+			host = p.Addr.String()
+		}
+		return "peer:" + host
+	}
+	return ""
+}
+
+// fingerprintOf hashes the deterministic encoding of a request.
+func fingerprintOf(req proto.Message) (string, error) {
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
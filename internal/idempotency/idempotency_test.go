@@ -0,0 +1,59 @@
+package idempotency
+
+import (
+	pb2 "awesomeProject/internal/orders/pb"
+	"awesomeProject/pkg/cache"
+	"context"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+// call returns the context of a request from host:port with the given
+// metadata.
+func call(host string, port int, pairs ...string) context.Context {
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(host), Port: port}})
+	return metadata.NewIncomingContext(ctx, metadata.Pairs(pairs...))
+}
+
+func TestKeysArePerCaller(t *testing.T) {
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	store := NewStore(log, cache.NewMemoryCache(), time.Hour)
+	req := &pb2.CreateCustomerReq{Name: "Ann"}
+
+	runs := 0
+	create := func(ctx context.Context) string {
+		res, err := Do(ctx, store, "CreateCustomer", req, func() (*pb2.CreateCustomerRes, error) {
+			runs++
+			return &pb2.CreateCustomerRes{Message: fmt.Sprintf("run %d", runs)}, nil
+		})
+		if err != nil {
+			t.Fatalf("Do: %v", err)
+		}
+		return res.GetMessage()
+	}
+
+	first := create(call("10.0.0.1", 40001, KeyHeader, "k1"))
+	// A retry from another connection of the same host is the same caller.
+	if retry := create(call("10.0.0.1", 40002, KeyHeader, "k1")); retry != first {
+		t.Errorf("retry got %q, want the stored %q", retry, first)
+	}
+	if other := create(call("10.0.0.2", 40001, KeyHeader, "k1")); other == first {
+		t.Errorf("another host got the response of the first")
+	}
+	// Credentials identify callers behind the same address.
+	alice := create(call("10.0.0.3", 40001, KeyHeader, "k1", "authorization", "Bearer alice"))
+	bob := create(call("10.0.0.3", 40001, KeyHeader, "k1", "authorization", "Bearer bob"))
+	if alice == bob {
+		t.Errorf("two credentials shared the key")
+	}
+	if runs != 4 {
+		t.Errorf("handler ran %d times, want 4", runs)
+	}
+}
@@ -0,0 +1,37 @@
+// Package interceptors holds the gRPC server interceptors every call goes
+// through: request ids, metrics, access logs, panic recovery and default
+// deadlines. ServerOptions puts them in order around the error translation
+// of package errs.
+package interceptors
+
+import (
+	"awesomeProject/internal/errs"
+	"awesomeProject/internal/metrics"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"time"
+)
+
+// ServerOptions chains the interceptors, outermost first: the request id
+// comes first so every log line has it, the metrics and the access log see
+// the final code, recovery catches panics from everything inside it, and
+// handler errors are translated to statuses innermost.
+func ServerOptions(log *logrus.Logger, timeout time.Duration, m *metrics.Metrics) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(
+			UnaryRequestID,
+			m.UnaryServerInterceptor,
+			UnaryAccessLog(log),
+			UnaryRecovery(log),
+			UnaryDeadline(timeout),
+			errs.UnaryServerInterceptor,
+		),
+		grpc.ChainStreamInterceptor(
+			StreamRequestID,
+			m.StreamServerInterceptor,
+			StreamAccessLog(log),
+			StreamRecovery(log),
+			errs.StreamServerInterceptor,
+		),
+	}
+}
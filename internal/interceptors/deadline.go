@@ -0,0 +1,35 @@
+package interceptors
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+// UnaryDeadline gives calls that come without a deadline one of timeout.
+// The repositories do not take a context, so the deadline does not stop
+// storage work; it only relabels the outcome: a call that fails after its
+// deadline passed or the client went away reports DEADLINE_EXCEEDED or
+// CANCELLED, whatever the handler made of it. Streams are long lived and
+// get no default deadline.
+func UnaryDeadline(timeout time.Duration) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if _, ok := ctx.Deadline(); !ok && timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		res, err := handler(ctx, req)
+		if err != nil {
+			switch ctx.Err() {
+			case context.DeadlineExceeded:
+				return res, status.Error(codes.DeadlineExceeded, "deadline exceeded")
+			case context.Canceled:
+				return res, status.Error(codes.Canceled, "request cancelled")
+			}
+		}
+		return res, err
+	}
+}
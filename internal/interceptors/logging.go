@@ -0,0 +1,55 @@
+package interceptors
+
+import (
+	"context"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+// UnaryAccessLog logs every call with its method, status code and latency
+// once it is answered.
+func UnaryAccessLog(log *logrus.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		res, err := handler(ctx, req)
+		logCall(log, ctx, info.FullMethod, start, err)
+		return res, err
+	}
+}
+
+// StreamAccessLog logs every stream when it ends.
+func StreamAccessLog(log *logrus.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		logCall(log, ss.Context(), info.FullMethod, start, err)
+		return err
+	}
+}
+
+// logCall logs successful calls at info level, the caller's mistakes at
+// warning and server failures at error.
+func logCall(log *logrus.Logger, ctx context.Context, method string, start time.Time, err error) {
+	code := status.Code(err)
+	entry := log.WithFields(logrus.Fields{
+		"method":     method,
+		"code":       code.String(),
+		"latency_ms": float64(time.Since(start).Microseconds()) / 1000,
+		"request_id": RequestID(ctx),
+	})
+	if p, ok := peer.FromContext(ctx); ok {
+		entry = entry.WithField("peer", p.Addr.String())
+	}
+	switch code {
+	case codes.OK:
+		entry.Info("gRPC call")
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable, codes.Unimplemented:
+		entry.WithField("error", status.Convert(err).Message()).Error("gRPC call failed")
+	default:
+		entry.WithField("error", status.Convert(err).Message()).Warning("gRPC call failed")
+	}
+}
@@ -0,0 +1,45 @@
+package interceptors
+
+import (
+	"context"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"runtime/debug"
+)
+
+// UnaryRecovery turns a panicking handler into an INTERNAL error instead
+// of a crashed server. The panic and its stack are logged.
+func UnaryRecovery(log *logrus.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (res interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recovered(log, ctx, info.FullMethod, r)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// StreamRecovery ends a panicking stream with an INTERNAL error.
+func StreamRecovery(log *logrus.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recovered(log, ss.Context(), info.FullMethod, r)
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+func recovered(log *logrus.Logger, ctx context.Context, method string, r interface{}) error {
+	log.WithFields(logrus.Fields{
+		"method":     method,
+		"request_id": RequestID(ctx),
+		"panic":      r,
+		"stack":      string(debug.Stack()),
+	}).Error("Recovered from a panic in a gRPC handler")
+	return status.Error(codes.Internal, "internal error")
+}
@@ -0,0 +1,45 @@
+package mapper
+
+import (
+	"awesomeProject/internal/entity"
+	pb2 "awesomeProject/internal/orders/pb"
+	"awesomeProject/internal/validation"
+)
+
+func CustomerToProto(customer *entity.Customer) *pb2.Customer {
+	return &pb2.Customer{
+		Id:        idToProto(customer.ID),
+		Name:      customer.Name,
+		Location:  LocationToProto(customer.Position),
+		CreatedAt: TimeToProto(customer.CreatedAt),
+		UpdatedAt: TimeToProto(customer.UpdatedAt),
+	}
+}
+
+func CustomerFromProto(c *pb2.Customer) (*entity.Customer, error) {
+	var violations validation.Violations
+	customer := &entity.Customer{
+		ID:        idFromProto(&violations, "id", c.GetId()),
+		Name:      c.GetName(),
+		Position:  LocationFromProto(c.GetLocation()),
+		CreatedAt: violations.Time("createdAt", c.GetCreatedAt()),
+		UpdatedAt: violations.Time("updatedAt", c.GetUpdatedAt()),
+	}
+	return customer, violations.Err()
+}
+
+// LocationToProto widens the stored float32 coordinates, which
+// LocationFromProto narrows back without loss.
+func LocationToProto(location entity.Location) *pb2.Location {
+	return &pb2.Location{
+		Lat: float64(location.Lat),
+		Lon: float64(location.Long),
+	}
+}
+
+func LocationFromProto(l *pb2.Location) entity.Location {
+	return entity.Location{
+		Lat:  float32(l.GetLat()),
+		Long: float32(l.GetLon()),
+	}
+}
@@ -0,0 +1,39 @@
+package mapper
+
+import (
+	"awesomeProject/internal/entity"
+	"awesomeProject/internal/events"
+	pb2 "awesomeProject/internal/orders/pb"
+)
+
+var eventTypeToProtoMap = map[events.Type]pb2.OrderEventType{
+	events.Created:       pb2.OrderEventType_ORDER_EVENT_TYPE_CREATED,
+	events.Updated:       pb2.OrderEventType_ORDER_EVENT_TYPE_UPDATED,
+	events.StatusChanged: pb2.OrderEventType_ORDER_EVENT_TYPE_STATUS_CHANGED,
+	events.Deleted:       pb2.OrderEventType_ORDER_EVENT_TYPE_DELETED,
+}
+
+// OrderEventToProto leaves the order out when the event has none.
+func OrderEventToProto(event events.Event) *pb2.OrderEvent {
+	res := &pb2.OrderEvent{
+		Type:           eventTypeToProtoMap[event.Type],
+		OrderId:        event.OrderID.Hex(),
+		PreviousStatus: StatusToProto(event.PreviousStatus),
+		Status:         StatusToProto(event.Status),
+		ResumeToken:    event.Token,
+	}
+	if event.Order != nil {
+		res.Order = OrderToProto(event.Order)
+	}
+	return res
+}
+
+// OrderSnapshotToProto reports the current state of an order.
+func OrderSnapshotToProto(order *entity.Order) *pb2.OrderEvent {
+	return &pb2.OrderEvent{
+		Type:    pb2.OrderEventType_ORDER_EVENT_TYPE_SNAPSHOT,
+		OrderId: order.ID.Hex(),
+		Order:   OrderToProto(order),
+		Status:  StatusToProto(order.Status),
+	}
+}
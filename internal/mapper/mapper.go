@@ -0,0 +1,51 @@
+// Package mapper converts entities to their protobuf messages and back.
+//
+// The conversions are lossless for stored entities: XFromProto(XToProto(x))
+// equals x as long as its times are in UTC at millisecond precision, which
+// is how the entities stamp and the stores return them. Unset times map to
+// unset timestamps and back to the zero time.
+//
+// The FromProto functions only convert; they report ids and timestamps that
+// cannot be parsed as an invalid argument error but leave checking required
+// fields and business rules to the handlers.
+package mapper
+
+import (
+	pb2 "awesomeProject/internal/orders/pb"
+	"awesomeProject/internal/validation"
+	"awesomeProject/pkg/money"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
+)
+
+func MoneyToProto(m money.Money) *pb2.Money {
+	return &pb2.Money{Amount: m.Amount, Currency: m.Currency}
+}
+
+func MoneyFromProto(m *pb2.Money) money.Money {
+	return money.New(m.GetAmount(), m.GetCurrency())
+}
+
+// TimeToProto leaves unset times out of the message.
+func TimeToProto(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// idToProto writes the hex form even for the zero id, so the id always
+// round trips.
+func idToProto(id primitive.ObjectID) *string {
+	hex := id.Hex()
+	return &hex
+}
+
+// idFromProto reads an id, leaving it zero when the message has none.
+func idFromProto(violations *validation.Violations, field string, hex string) primitive.ObjectID {
+	if hex == "" {
+		return primitive.NilObjectID
+	}
+	return violations.ObjectID(field, hex)
+}
@@ -0,0 +1,227 @@
+package mapper
+
+import (
+	"awesomeProject/internal/entity"
+	"awesomeProject/pkg/money"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// stamp is a time as the entities store it: UTC at millisecond precision.
+var stamp = time.Date(2026, time.March, 14, 15, 9, 26, 535000000, time.UTC)
+
+func TestOrderRoundTrip(t *testing.T) {
+	line := entity.OrderLine{
+		ProductID:   primitive.NewObjectID(),
+		Name:        "Widget",
+		Description: "A widget",
+		SKU:         "WID-001",
+		Quantity:    3,
+		UnitPrice:   money.New(250, "EUR"),
+		LineTotal:   money.New(750, "EUR"),
+		CreatedAt:   stamp,
+	}
+	tests := []struct {
+		name  string
+		order *entity.Order
+	}{
+		{name: "empty", order: &entity.Order{}},
+		{name: "full", order: &entity.Order{
+			ID:           primitive.NewObjectID(),
+			Status:       entity.Transit,
+			OrderNo:      "ORD-2026-000042",
+			Items:        []entity.OrderLine{line},
+			CustomerId:   primitive.NewObjectID(),
+			DeliveryDate: stamp.Add(48 * time.Hour),
+			CreatedAt:    stamp,
+			UpdatedAt:    stamp.Add(time.Minute),
+			Subtotal:     money.New(750, "EUR"),
+			Tax:          money.New(143, "EUR"),
+			Shipping:     money.New(499, "EUR"),
+			Total:        money.New(1392, "EUR"),
+		}},
+		{name: "sku only line", order: &entity.Order{
+			ID:     primitive.NewObjectID(),
+			Status: entity.Processing,
+			Items:  []entity.OrderLine{{SKU: "LEGACY-7", Quantity: 1}},
+		}},
+		{name: "zero times and money", order: &entity.Order{
+			ID:     primitive.NewObjectID(),
+			Status: entity.Cancelled,
+			Items:  []entity.OrderLine{{ProductID: primitive.NewObjectID(), SKU: "FREE", Quantity: 2}},
+			Total:  money.Zero("EUR"),
+		}},
+		{name: "several lines", order: &entity.Order{
+			ID:     primitive.NewObjectID(),
+			Status: entity.Delivered,
+			Items:  []entity.OrderLine{line, {SKU: "B", Quantity: 10000, UnitPrice: money.New(1, "JPY")}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := OrderFromProto(OrderToProto(tt.order))
+			if err != nil {
+				t.Fatalf("OrderFromProto: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.order) {
+				t.Errorf("round trip changed the order\n got: %+v\nwant: %+v", got, tt.order)
+			}
+		})
+	}
+}
+
+func TestProductRoundTrip(t *testing.T) {
+	tests := []struct {
+		name    string
+		product *entity.Product
+	}{
+		{name: "empty", product: &entity.Product{}},
+		{name: "full", product: &entity.Product{
+			ID:          primitive.NewObjectID(),
+			Name:        "Widget",
+			Description: "A widget",
+			Price:       money.New(250, "EUR"),
+			SKU:         "WID-001",
+			Archived:    true,
+			CreatedAt:   stamp,
+			UpdatedAt:   stamp.Add(time.Hour),
+		}},
+		{name: "zero price and times", product: &entity.Product{
+			ID:   primitive.NewObjectID(),
+			Name: "Sample",
+			SKU:  "SAMPLE",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ProductFromProto(ProductToProto(tt.product))
+			if err != nil {
+				t.Fatalf("ProductFromProto: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.product) {
+				t.Errorf("round trip changed the product\n got: %+v\nwant: %+v", got, tt.product)
+			}
+		})
+	}
+}
+
+func TestCustomerRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		customer *entity.Customer
+	}{
+		{name: "empty", customer: &entity.Customer{}},
+		{name: "full", customer: &entity.Customer{
+			ID:        primitive.NewObjectID(),
+			Name:      "Ann",
+			Position:  entity.Location{Lat: 52.520008, Long: 13.404954},
+			CreatedAt: stamp,
+			UpdatedAt: stamp.Add(time.Second),
+		}},
+		{name: "zero times", customer: &entity.Customer{
+			ID:       primitive.NewObjectID(),
+			Name:     "Bob",
+			Position: entity.Location{Lat: -33.8688, Long: 151.2093},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CustomerFromProto(CustomerToProto(tt.customer))
+			if err != nil {
+				t.Fatalf("CustomerFromProto: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.customer) {
+				t.Errorf("round trip changed the customer\n got: %+v\nwant: %+v", got, tt.customer)
+			}
+		})
+	}
+}
+
+func TestLocationRoundTrip(t *testing.T) {
+	tests := []entity.Location{
+		{},
+		{Lat: 90, Long: 180},
+		{Lat: -90, Long: -180},
+		{Lat: 0.1, Long: -0.1},
+		{Lat: math.SmallestNonzeroFloat32, Long: math.MaxFloat32},
+	}
+	for _, location := range tests {
+		if got := LocationFromProto(LocationToProto(location)); got != location {
+			t.Errorf("LocationFromProto(LocationToProto(%+v)) = %+v", location, got)
+		}
+	}
+}
+
+// fuzzTime maps any number to a time a timestamp can hold, in UTC at
+// millisecond precision.
+func fuzzTime(millis int64) time.Time {
+	// From the Unix epoch up to the end of year 9999.
+	const span = 253402300800000
+	millis %= span
+	if millis < 0 {
+		millis += span
+	}
+	return time.UnixMilli(millis).UTC()
+}
+
+var fuzzStatuses = []entity.OrderStatus{"", entity.Processing, entity.Transit, entity.Delivered, entity.Cancelled}
+
+func FuzzOrderRoundTrip(f *testing.F) {
+	f.Add("ORD-2026-000001", "WID-001", "Widget", int64(3), int64(250), "EUR", int64(1773500966535), uint8(1))
+	f.Add("", "", "", int64(0), int64(0), "", int64(0), uint8(0))
+	f.Fuzz(func(t *testing.T, orderNo string, sku string, name string, quantity int64, amount int64, currency string, millis int64, status uint8) {
+		order := &entity.Order{
+			ID:      primitive.NewObjectID(),
+			Status:  fuzzStatuses[int(status)%len(fuzzStatuses)],
+			OrderNo: orderNo,
+			Items: []entity.OrderLine{{
+				ProductID: primitive.NewObjectID(),
+				Name:      name,
+				SKU:       sku,
+				Quantity:  quantity,
+				UnitPrice: money.New(amount, currency),
+				LineTotal: money.New(amount*quantity, currency),
+				CreatedAt: fuzzTime(millis),
+			}},
+			CustomerId:   primitive.NewObjectID(),
+			DeliveryDate: fuzzTime(millis + 1),
+			CreatedAt:    fuzzTime(millis),
+			UpdatedAt:    fuzzTime(millis * 7),
+			Total:        money.New(amount, currency),
+		}
+		got, err := OrderFromProto(OrderToProto(order))
+		if err != nil {
+			t.Fatalf("OrderFromProto: %v", err)
+		}
+		if !reflect.DeepEqual(got, order) {
+			t.Errorf("round trip changed the order\n got: %+v\nwant: %+v", got, order)
+		}
+	})
+}
+
+func FuzzCustomerRoundTrip(f *testing.F) {
+	f.Add("Ann", float32(52.52), float32(13.405), int64(1773500966535))
+	f.Add("", float32(0), float32(0), int64(0))
+	f.Fuzz(func(t *testing.T, name string, lat float32, long float32, millis int64) {
+		if lat != lat || long != long {
+			t.Skip("NaN never equals itself")
+		}
+		customer := &entity.Customer{
+			ID:        primitive.NewObjectID(),
+			Name:      name,
+			Position:  entity.Location{Lat: lat, Long: long},
+			CreatedAt: fuzzTime(millis),
+			UpdatedAt: fuzzTime(millis / 3),
+		}
+		got, err := CustomerFromProto(CustomerToProto(customer))
+		if err != nil {
+			t.Fatalf("CustomerFromProto: %v", err)
+		}
+		if !reflect.DeepEqual(got, customer) {
+			t.Errorf("round trip changed the customer\n got: %+v\nwant: %+v", got, customer)
+		}
+	})
+}
@@ -0,0 +1,90 @@
+package mapper
+
+import (
+	"awesomeProject/internal/entity"
+	pb2 "awesomeProject/internal/orders/pb"
+	"awesomeProject/internal/validation"
+	"fmt"
+)
+
+func OrderToProto(order *entity.Order) *pb2.Order {
+	var lines = make([]*pb2.OrderLine, 0, len(order.Items))
+	for _, item := range order.Items {
+		lines = append(lines, OrderLineToProto(item))
+	}
+
+	return &pb2.Order{
+		Id:           idToProto(order.ID),
+		Status:       StatusToProto(order.Status),
+		Lines:        lines,
+		DeliveryDate: TimeToProto(order.DeliveryDate),
+		OrderNo:      order.OrderNo,
+		CustomerId:   order.CustomerId.Hex(),
+		Subtotal:     MoneyToProto(order.Subtotal),
+		Tax:          MoneyToProto(order.Tax),
+		Shipping:     MoneyToProto(order.Shipping),
+		Total:        MoneyToProto(order.Total),
+		CreatedAt:    TimeToProto(order.CreatedAt),
+		UpdatedAt:    TimeToProto(order.UpdatedAt),
+	}
+}
+
+// OrderFromProto converts every field of the message, including the ones
+// clients may not set such as totals. Unknown statuses become empty.
+func OrderFromProto(o *pb2.Order) (*entity.Order, error) {
+	var violations validation.Violations
+	status, _ := StatusFromProto(o.GetStatus())
+	order := &entity.Order{
+		ID:           idFromProto(&violations, "id", o.GetId()),
+		Status:       status,
+		OrderNo:      o.GetOrderNo(),
+		CustomerId:   idFromProto(&violations, "customerId", o.GetCustomerId()),
+		DeliveryDate: violations.Time("deliveryDate", o.GetDeliveryDate()),
+		CreatedAt:    violations.Time("createdAt", o.GetCreatedAt()),
+		UpdatedAt:    violations.Time("updatedAt", o.GetUpdatedAt()),
+		Subtotal:     MoneyFromProto(o.GetSubtotal()),
+		Tax:          MoneyFromProto(o.GetTax()),
+		Shipping:     MoneyFromProto(o.GetShipping()),
+		Total:        MoneyFromProto(o.GetTotal()),
+	}
+	for i, line := range o.GetLines() {
+		order.Items = append(order.Items, orderLineFromProto(&violations, fmt.Sprintf("lines[%d]", i), line))
+	}
+	return order, violations.Err()
+}
+
+func OrderLineToProto(line entity.OrderLine) *pb2.OrderLine {
+	return &pb2.OrderLine{
+		ProductId:   line.ProductID.Hex(),
+		Sku:         line.SKU,
+		Name:        line.Name,
+		Description: line.Description,
+		Quantity:    line.Quantity,
+		UnitPrice:   MoneyToProto(line.UnitPrice),
+		LineTotal:   MoneyToProto(line.LineTotal),
+		CreatedAt:   TimeToProto(line.CreatedAt),
+	}
+}
+
+func OrderLineFromProto(l *pb2.OrderLine) (entity.OrderLine, error) {
+	var violations validation.Violations
+	line := orderLineFromProto(&violations, "", l)
+	return line, violations.Err()
+}
+
+func orderLineFromProto(violations *validation.Violations, field string, l *pb2.OrderLine) entity.OrderLine {
+	prefix := field
+	if prefix != "" {
+		prefix += "."
+	}
+	return entity.OrderLine{
+		ProductID:   idFromProto(violations, prefix+"productId", l.GetProductId()),
+		Name:        l.GetName(),
+		Description: l.GetDescription(),
+		SKU:         l.GetSku(),
+		Quantity:    l.GetQuantity(),
+		UnitPrice:   MoneyFromProto(l.GetUnitPrice()),
+		LineTotal:   MoneyFromProto(l.GetLineTotal()),
+		CreatedAt:   violations.Time(prefix+"createdAt", l.GetCreatedAt()),
+	}
+}
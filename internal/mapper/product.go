@@ -0,0 +1,36 @@
+package mapper
+
+import (
+	"awesomeProject/internal/entity"
+	pb2 "awesomeProject/internal/orders/pb"
+	"awesomeProject/internal/validation"
+)
+
+func ProductToProto(product *entity.Product) *pb2.Product {
+	description := product.Description
+	return &pb2.Product{
+		Id:          idToProto(product.ID),
+		Name:        product.Name,
+		Description: &description,
+		Sku:         product.SKU,
+		Archived:    product.Archived,
+		Price:       MoneyToProto(product.Price),
+		CreatedAt:   TimeToProto(product.CreatedAt),
+		UpdatedAt:   TimeToProto(product.UpdatedAt),
+	}
+}
+
+func ProductFromProto(p *pb2.Product) (*entity.Product, error) {
+	var violations validation.Violations
+	product := &entity.Product{
+		ID:          idFromProto(&violations, "id", p.GetId()),
+		Name:        p.GetName(),
+		Description: p.GetDescription(),
+		Price:       MoneyFromProto(p.GetPrice()),
+		SKU:         p.GetSku(),
+		Archived:    p.GetArchived(),
+		CreatedAt:   violations.Time("createdAt", p.GetCreatedAt()),
+		UpdatedAt:   violations.Time("updatedAt", p.GetUpdatedAt()),
+	}
+	return product, violations.Err()
+}
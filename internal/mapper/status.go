@@ -0,0 +1,30 @@
+package mapper
+
+import (
+	"awesomeProject/internal/entity"
+	pb2 "awesomeProject/internal/orders/pb"
+)
+
+var statusToProtoMap = map[entity.OrderStatus]pb2.OrderStatus{
+	entity.Processing: pb2.OrderStatus_ORDER_STATUS_PROCESSING,
+	entity.Transit:    pb2.OrderStatus_ORDER_STATUS_TRANSIT,
+	entity.Delivered:  pb2.OrderStatus_ORDER_STATUS_DELIVERED,
+	entity.Cancelled:  pb2.OrderStatus_ORDER_STATUS_CANCELLED,
+}
+
+// StatusToProto maps a stored status to the enum, UNSPECIFIED when the
+// stored value is not a known status.
+func StatusToProto(status entity.OrderStatus) pb2.OrderStatus {
+	return statusToProtoMap[status]
+}
+
+// StatusFromProto maps the enum to a stored status; ok is false for
+// UNSPECIFIED and unknown values.
+func StatusFromProto(status pb2.OrderStatus) (entity.OrderStatus, bool) {
+	for stored, wire := range statusToProtoMap {
+		if wire == status {
+			return stored, true
+		}
+	}
+	return "", false
+}
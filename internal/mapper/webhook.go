@@ -0,0 +1,57 @@
+package mapper
+
+import (
+	"awesomeProject/internal/entity"
+	"awesomeProject/internal/events"
+	pb2 "awesomeProject/internal/orders/pb"
+)
+
+var deliveryStatusToProtoMap = map[entity.DeliveryStatus]pb2.WebhookDeliveryStatus{
+	entity.DeliveryPending:   pb2.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_PENDING,
+	entity.DeliveryDelivered: pb2.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_DELIVERED,
+	entity.DeliveryDead:      pb2.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_DEAD,
+}
+
+// EventTypeFromProto maps the enum to an event type; ok is false for
+// UNSPECIFIED, SNAPSHOT and unknown values.
+func EventTypeFromProto(eventType pb2.OrderEventType) (events.Type, bool) {
+	for stored, wire := range eventTypeToProtoMap {
+		if wire == eventType {
+			return stored, true
+		}
+	}
+	return "", false
+}
+
+// WebhookToProto leaves the secret out.
+func WebhookToProto(webhook *entity.Webhook) *pb2.Webhook {
+	eventTypes := make([]pb2.OrderEventType, 0, len(webhook.EventTypes))
+	for _, eventType := range webhook.EventTypes {
+		eventTypes = append(eventTypes, eventTypeToProtoMap[events.Type(eventType)])
+	}
+	return &pb2.Webhook{
+		Id:         webhook.ID.Hex(),
+		Url:        webhook.URL,
+		EventTypes: eventTypes,
+		Disabled:   webhook.Disabled,
+		CreatedAt:  TimeToProto(webhook.CreatedAt),
+		UpdatedAt:  TimeToProto(webhook.UpdatedAt),
+	}
+}
+
+// DeliveryToProto leaves the payload out.
+func DeliveryToProto(delivery *entity.WebhookDelivery) *pb2.WebhookDelivery {
+	return &pb2.WebhookDelivery{
+		Id:            delivery.ID.Hex(),
+		WebhookId:     delivery.WebhookID.Hex(),
+		EventId:       delivery.EventID,
+		EventType:     eventTypeToProtoMap[events.Type(delivery.EventType)],
+		Status:        deliveryStatusToProtoMap[delivery.Status],
+		Attempts:      int32(delivery.Attempts),
+		LastError:     delivery.LastError,
+		ResponseCode:  int32(delivery.ResponseCode),
+		NextAttemptAt: TimeToProto(delivery.NextAttemptAt),
+		CreatedAt:     TimeToProto(delivery.CreatedAt),
+		DeliveredAt:   TimeToProto(delivery.DeliveredAt),
+	}
+}
@@ -0,0 +1,49 @@
+package metrics
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"strings"
+	"time"
+)
+
+// UnaryServerInterceptor counts and times the unary calls. It must run
+// outside the interceptors that turn errors into statuses, so that it sees
+// the codes the clients get.
+func (m *Metrics) UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	res, err := handler(ctx, req)
+	m.observeCall("unary", info.FullMethod, start, err)
+	return res, err
+}
+
+// StreamServerInterceptor counts and times the streams when they end.
+func (m *Metrics) StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	kind := "bidi_stream"
+	switch {
+	case info.IsServerStream && !info.IsClientStream:
+		kind = "server_stream"
+	case info.IsClientStream && !info.IsServerStream:
+		kind = "client_stream"
+	}
+	m.observeCall(kind, info.FullMethod, start, err)
+	return err
+}
+
+func (m *Metrics) observeCall(kind string, fullMethod string, start time.Time, err error) {
+	service, method := splitMethod(fullMethod)
+	m.rpcHandled.WithLabelValues(kind, service, method, status.Code(err).String()).Inc()
+	m.rpcDuration.WithLabelValues(kind, service, method).Observe(time.Since(start).Seconds())
+}
+
+// splitMethod splits /package.Service/Method into its service and method.
+func splitMethod(fullMethod string) (string, string) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(fullMethod, "/"); i >= 0 {
+		return fullMethod[:i], fullMethod[i+1:]
+	}
+	return "unknown", fullMethod
+}
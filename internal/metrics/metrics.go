@@ -0,0 +1,117 @@
+// Package metrics exposes the server's Prometheus metrics: gRPC calls by
+// method and status code, the latency of store and cache operations, cache
+// hit ratios and the order stream subscribers.
+package metrics
+
+import (
+	"awesomeProject/internal/events"
+	"awesomeProject/pkg/cache"
+	"awesomeProject/pkg/db"
+	"errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net/http"
+	"time"
+)
+
+// Metrics holds the collectors in a registry of their own, together with
+// the Go runtime and process metrics.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	rpcHandled  *prometheus.CounterVec
+	rpcDuration *prometheus.HistogramVec
+	store       *prometheus.HistogramVec
+	cache       *prometheus.HistogramVec
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		rpcHandled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grpc_server_handled_total",
+			Help: "gRPC calls completed, by method and status code.",
+		}, []string{"grpc_type", "grpc_service", "grpc_method", "grpc_code"}),
+		rpcDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "grpc_server_handling_seconds",
+			Help:    "Time taken by gRPC calls until they completed, streams included.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"grpc_type", "grpc_service", "grpc_method"}),
+		store: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "store_operation_duration_seconds",
+			Help:    "Time taken by store operations, by outcome: store methods on Mongo, statements on SQL.",
+			Buckets: []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"operation", "collection", "result"}),
+		cache: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "cache_operation_duration_seconds",
+			Help:    "Time taken by cache operations, by outcome; reads are hits or misses.",
+			Buckets: []float64{.0001, .00025, .0005, .001, .0025, .005, .01, .025, .05, .1, .25},
+		}, []string{"cache", "operation", "result"}),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.rpcHandled,
+		m.rpcDuration,
+		m.store,
+		m.cache,
+	)
+	return m
+}
+
+// Handler serves the metrics in the Prometheus text format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// ObserveStore is a db.Observer. Not found is an outcome of its own, as
+// lookups of missing documents are routine.
+func (m *Metrics) ObserveStore(operation string, collectionName string, took time.Duration, err error) {
+	result := "ok"
+	switch {
+	case errors.Is(err, db.ErrNotFound):
+		result = "not_found"
+	case errors.Is(err, db.ErrDuplicateKey):
+		result = "duplicate_key"
+	case err != nil:
+		result = "error"
+	}
+	m.store.WithLabelValues(operation, collectionName, result).Observe(took.Seconds())
+}
+
+// CacheObserver returns a cache.Observer for the cache called name, so
+// that the hit ratios of different uses are told apart.
+func (m *Metrics) CacheObserver(name string) cache.Observer {
+	return func(operation string, result string, took time.Duration) {
+		m.cache.WithLabelValues(name, operation, result).Observe(took.Seconds())
+	}
+}
+
+// WatchBroadcaster exports the subscribers of the order streams and the
+// counters of the broadcaster behind them.
+func (m *Metrics) WatchBroadcaster(broadcaster *events.Broadcaster) {
+	stat := func(value func(events.Stats) float64) func() float64 {
+		return func() float64 {
+			return value(broadcaster.Stats())
+		}
+	}
+	m.registry.MustRegister(
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "order_stream_subscribers",
+			Help: "Clients currently subscribed to GetOrdersStream.",
+		}, stat(func(s events.Stats) float64 { return float64(s.Subscribers) })),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Name: "order_stream_events_published_total",
+			Help: "Order events published to the stream subscribers.",
+		}, stat(func(s events.Stats) float64 { return float64(s.Published) })),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Name: "order_stream_events_dropped_total",
+			Help: "Order events skipped for subscribers that fell behind.",
+		}, stat(func(s events.Stats) float64 { return float64(s.Dropped) })),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Name: "order_stream_disconnects_total",
+			Help: "Subscribers disconnected for falling behind.",
+		}, stat(func(s events.Stats) float64 { return float64(s.Disconnected) })),
+	)
+}
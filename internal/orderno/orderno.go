@@ -0,0 +1,105 @@
+// Package orderno generates human readable order numbers such as
+// ORD-2026-000123 from a configurable format and a counter.
+package orderno
+
+import (
+	"awesomeProject/pkg/cache"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultFormat numbers orders per year with six digits.
+const DefaultFormat = "ORD-{year}-{seq:6}"
+
+// Sequence hands out increasing numbers per counter name.
+// repository.SequenceRepository implements it on top of the store.
+type Sequence interface {
+	Next(name string) (int64, error)
+}
+
+// CacheSequence counts with Redis INCR. The counters must survive Redis
+// restarts, otherwise numbers are handed out again and rejected by the
+// unique index on orderNo.
+type CacheSequence struct {
+	Cache cache.ICache
+}
+
+func (s CacheSequence) Next(name string) (int64, error) {
+	return s.Cache.Incr("sequence-" + name)
+}
+
+var tokenPattern = regexp.MustCompile(`\{([a-z]+)(?::(\d+))?\}`)
+
+// Generator expands a format with the date of the order and the next value
+// of its counter. The format takes these tokens:
+//
+//	{year}   four digit year
+//	{month}  two digit month
+//	{day}    two digit day
+//	{seq:N}  counter, zero padded to N digits (just {seq} for no padding)
+//
+// The counter is named after the format with the date tokens expanded, so
+// ORD-{year}-{seq:6} restarts at 1 every year.
+type Generator struct {
+	format   string
+	sequence Sequence
+}
+
+// NewGenerator checks that format has exactly one {seq} token and no
+// unknown ones.
+func NewGenerator(format string, sequence Sequence) (*Generator, error) {
+	seqTokens := 0
+	for _, match := range tokenPattern.FindAllStringSubmatch(format, -1) {
+		switch match[1] {
+		case "year", "month", "day":
+			if match[2] != "" {
+				return nil, fmt.Errorf("orderno: %s takes no width", match[0])
+			}
+		case "seq":
+			seqTokens++
+			if width, _ := strconv.Atoi(match[2]); width > 18 {
+				return nil, fmt.Errorf("orderno: %s is wider than 18 digits", match[0])
+			}
+		default:
+			return nil, fmt.Errorf("orderno: unknown token %s in %q", match[0], format)
+		}
+	}
+	if seqTokens != 1 {
+		return nil, fmt.Errorf("orderno: format %q needs exactly one {seq} token", format)
+	}
+	return &Generator{format: format, sequence: sequence}, nil
+}
+
+// Next returns the number of an order placed at now, read in the zone of
+// now; the server passes UTC like every stored time.
+func (g *Generator) Next(now time.Time) (string, error) {
+	name := tokenPattern.ReplaceAllStringFunc(g.format, func(token string) string {
+		if strings.HasPrefix(token, "{seq") {
+			return token
+		}
+		return dateToken(token, now)
+	})
+	seq, err := g.sequence.Next("orderNo:" + name)
+	if err != nil {
+		return "", fmt.Errorf("orderno: next %s: %w", name, err)
+	}
+	return tokenPattern.ReplaceAllStringFunc(name, func(token string) string {
+		match := tokenPattern.FindStringSubmatch(token)
+		width, _ := strconv.Atoi(match[2])
+		return fmt.Sprintf("%0*d", width, seq)
+	}), nil
+}
+
+func dateToken(token string, now time.Time) string {
+	switch token {
+	case "{year}":
+		return fmt.Sprintf("%04d", now.Year())
+	case "{month}":
+		return fmt.Sprintf("%02d", int(now.Month()))
+	default:
+		return fmt.Sprintf("%02d", now.Day())
+	}
+}
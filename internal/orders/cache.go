@@ -0,0 +1,61 @@
+package orders
+
+import (
+	pb2 "awesomeProject/internal/orders/pb"
+	"encoding/json"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
+	"time"
+)
+
+const orderCacheTTL = 24 * time.Hour
+
+func orderCacheKey(id string) string {
+	return fmt.Sprintf("proto-%s", id)
+}
+
+// cacheOrder stores the wire form of an order under proto-<id>.
+func (s *OrderServer) cacheOrder(p *pb2.Order) {
+	b, err := proto.Marshal(p)
+	if err != nil {
+		logrus.Warning("Error marshalling proto", err)
+		return
+	}
+	if err := s.Cache.Set(orderCacheKey(p.GetId()), b, orderCacheTTL); err != nil {
+		logrus.Warning("Cache error ", err)
+	}
+}
+
+// cachedOrder returns the cached order, or nil on a miss. Cache failures
+// count as misses so reads fall back to the store.
+func (s *OrderServer) cachedOrder(id string) *pb2.Order {
+	raw, err := s.Cache.Get(orderCacheKey(id))
+	if err != nil {
+		logrus.Warning("Cache error ", err)
+		return nil
+	}
+	if raw == nil {
+		return nil
+	}
+
+	// Set stores values as JSON, which turns the proto bytes into base64.
+	var b []byte
+	if err := json.Unmarshal(raw, &b); err != nil {
+		logrus.Warning("Corrupt cached order ", id, err)
+		return nil
+	}
+	order := &pb2.Order{}
+	if err := proto.Unmarshal(b, order); err != nil {
+		logrus.Warning("Corrupt cached order ", id, err)
+		return nil
+	}
+	return order
+}
+
+// invalidateOrder drops the cached copy of an order after it changed.
+func (s *OrderServer) invalidateOrder(id string) {
+	if err := s.Cache.Delete(orderCacheKey(id)); err != nil {
+		logrus.Warning("Cache error ", err)
+	}
+}
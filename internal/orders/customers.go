@@ -0,0 +1,187 @@
+package orders
+
+import (
+	"awesomeProject/internal/entity"
+	"awesomeProject/internal/errs"
+	"awesomeProject/internal/idempotency"
+	"awesomeProject/internal/mapper"
+	pb2 "awesomeProject/internal/orders/pb"
+	"awesomeProject/internal/repository"
+	"awesomeProject/internal/validation"
+	"awesomeProject/pkg/db"
+	"awesomeProject/pkg/utils"
+	"context"
+	"errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CreateCustomer adds a customer once per idempotency key.
+func (s *OrderServer) CreateCustomer(ctx context.Context, req *pb2.CreateCustomerReq) (*pb2.CreateCustomerRes, error) {
+	return idempotency.Do(ctx, s.Idempotency, "CreateCustomer", req, func() (*pb2.CreateCustomerRes, error) {
+		return s.createCustomer(req)
+	})
+}
+
+func (s *OrderServer) createCustomer(req *pb2.CreateCustomerReq) (*pb2.CreateCustomerRes, error) {
+	newCustomer := entity.NewCustomer()
+
+	newCustomer.ID = primitive.NewObjectID()
+	newCustomer.Name = req.GetName()
+	p := entity.Location{
+		Lat:  req.GetLat(),
+		Long: req.GetLon(),
+	}
+
+	newCustomer.Position = p
+	var violations validation.Violations
+	violations.Struct("", newCustomer)
+	if err := violations.Err(); err != nil {
+		return nil, err
+	}
+
+	if _, err := newCustomer.Persist(s.Customers); err != nil {
+		return nil, errs.Wrap(err, "failed to create customer")
+	}
+
+	return &pb2.CreateCustomerRes{
+		Success:  true,
+		Message:  "Customer created Successfully ",
+		Customer: mapper.CustomerToProto(newCustomer),
+	}, nil
+}
+
+func (s *OrderServer) GetCustomer(ctx context.Context, req *pb2.GetCustomerReq) (*pb2.GetCustomerRes, error) {
+	var violations validation.Violations
+	id := violations.ObjectID("id", req.GetId())
+	if err := violations.Err(); err != nil {
+		return nil, err
+	}
+
+	customer, err := s.getCustomer(id)
+	if err != nil {
+		return nil, err
+	}
+	return &pb2.GetCustomerRes{Customer: mapper.CustomerToProto(customer)}, nil
+}
+
+// ListCustomers returns one page of customers, oldest first. Clients pass
+// nextPageToken back as pageToken until it comes back empty.
+func (s *OrderServer) ListCustomers(ctx context.Context, req *pb2.ListCustomersReq) (*pb2.ListCustomersRes, error) {
+	var violations validation.Violations
+	pageSize := int64(req.GetPageSize())
+	switch {
+	case pageSize < 0:
+		violations.Add("pageSize", "must not be negative")
+	case pageSize == 0:
+		pageSize = defaultPageSize
+	case pageSize > maxPageSize:
+		pageSize = maxPageSize
+	}
+	if err := violations.Err(); err != nil {
+		return nil, err
+	}
+
+	query := db.Query{Sort: "createdAt", Limit: pageSize, PageToken: req.GetPageToken()}
+	customers, next, err := s.Customers.Find(utils.KeyValue{}, query)
+	if errors.Is(err, db.ErrInvalidPageToken) {
+		return nil, errs.NewFieldViolation("pageToken", "is invalid or belongs to a different query")
+	}
+	if err != nil {
+		return nil, errs.Wrap(err, "failed to list customers")
+	}
+
+	res := &pb2.ListCustomersRes{Customers: make([]*pb2.Customer, 0, len(customers)), NextPageToken: next}
+	for _, customer := range customers {
+		res.Customers = append(res.Customers, mapper.CustomerToProto(customer))
+	}
+	return res, nil
+}
+
+// UpdateCustomer replaces the name and location of a customer.
+func (s *OrderServer) UpdateCustomer(ctx context.Context, req *pb2.UpdateCustomerReq) (*pb2.UpdateCustomerRes, error) {
+	reqCustomer := req.GetCustomer()
+	if reqCustomer == nil {
+		return nil, errs.NewFieldViolation("customer", "is required")
+	}
+
+	var violations validation.Violations
+	id := violations.ObjectID("customer.id", reqCustomer.GetId())
+	update := entity.NewCustomer()
+	update.Name = reqCustomer.GetName()
+	update.Position = mapper.LocationFromProto(reqCustomer.GetLocation())
+	violations.Struct("customer", update)
+	if err := violations.Err(); err != nil {
+		return nil, err
+	}
+
+	customer, err := s.getCustomer(id)
+	if err != nil {
+		return nil, err
+	}
+	customer.Name = update.Name
+	customer.Position = update.Position
+	if customer.CreatedAt.IsZero() {
+		// Customers created before CreatedAt was set would otherwise be
+		// inserted again instead of replaced.
+		customer.CreatedAt = customer.ID.Timestamp()
+	}
+	if _, err := customer.Persist(s.Customers); err != nil {
+		return nil, errs.Wrap(err, "failed to update customer")
+	}
+
+	return &pb2.UpdateCustomerRes{Customer: mapper.CustomerToProto(customer)}, nil
+}
+
+// DeleteCustomer removes a customer that has no open orders left. Closed
+// orders keep referring to the deleted customer's id.
+func (s *OrderServer) DeleteCustomer(ctx context.Context, req *pb2.DeleteCustomerReq) (*pb2.DeleteCustomerRes, error) {
+	var violations validation.Violations
+	id := violations.ObjectID("id", req.GetId())
+	if err := violations.Err(); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.getCustomer(id); err != nil {
+		return nil, err
+	}
+
+	open := bson.A{}
+	for _, st := range entity.OpenStatuses() {
+		open = append(open, st)
+	}
+	// Counting in the transaction of the delete keeps an order created in
+	// between from being left with a deleted customer.
+	err := s.Transaction(func(tx *repository.Repositories) error {
+		openOrders, err := tx.Orders.Count(utils.KeyValue{"customerId": id, "status": bson.M{"$in": open}})
+		if err != nil {
+			return errs.Wrap(err, "failed to count open orders")
+		}
+		if openOrders > 0 {
+			return errs.NewFailedPrecondition("customer %s has %d open orders", id.Hex(), openOrders)
+		}
+		if err := tx.Customers.Delete(id); err != nil {
+			return errs.Wrap(err, "failed to delete customer")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb2.DeleteCustomerRes{
+		Success: true,
+		Message: "Customer deleted",
+	}, nil
+}
+
+// getCustomer loads a customer by id, reporting a missing one as not found.
+func (s *OrderServer) getCustomer(id primitive.ObjectID) (*entity.Customer, error) {
+	customer, err := entity.NewCustomer().GetCustomer(s.Customers, utils.KeyValue{"_id": id})
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, errs.NewNotFound("customer %s not found", id.Hex())
+	}
+	if err != nil {
+		return nil, errs.Wrap(err, "failed to get customer")
+	}
+	return customer, nil
+}
@@ -2,20 +2,25 @@ package orders
 
 import (
 	"awesomeProject/internal/entity"
+	"awesomeProject/internal/errs"
+	"awesomeProject/internal/events"
+	"awesomeProject/internal/idempotency"
+	"awesomeProject/internal/mapper"
+	"awesomeProject/internal/orderno"
 	pb2 "awesomeProject/internal/orders/pb"
+	"awesomeProject/internal/outbox"
+	"awesomeProject/internal/repository"
+	"awesomeProject/internal/validation"
 	"awesomeProject/pkg/cache"
 	"awesomeProject/pkg/db"
+	"awesomeProject/pkg/money"
 	"awesomeProject/pkg/utils"
 	"context"
+	"errors"
 	"fmt"
-	"github.com/go-playground/validator/v10"
-	"github.com/mitchellh/mapstructure"
 	"github.com/sirupsen/logrus"
-	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
-	"go.mongodb.org/mongo-driver/mongo"
-	"google.golang.org/protobuf/proto"
-	"reflect"
+	"strings"
 	"time"
 )
 
@@ -26,278 +31,331 @@ func Ptr[T any](v T) *T {
 
 type OrderServer struct {
 	pb2.UnimplementedOrdersServer
-	Log   *logrus.Logger
-	Store *db.MongoStore
-	Cache *cache.RedisCache
+	Log       *logrus.Logger
+	Orders    repository.OrderRepository
+	Customers repository.CustomerRepository
+	Products  repository.ProductRepository
+	Cache     cache.ICache
+	Pricing   entity.Pricing
+	Numbers   *orderno.Generator
+	Events    *events.Broadcaster
+	// Transaction commits order changes together with their outbox
+	// messages.
+	Transaction func(fn func(tx *repository.Repositories) error) error
+	// Idempotency answers retried CreateOrder and CreateCustomer calls.
+	Idempotency *idempotency.Store
 }
 
+// GetOrders returns one page of the orders matching the request filters.
+// Clients pass nextPageToken back as pageToken until it comes back empty.
 func (s *OrderServer) GetOrders(ctx context.Context, req *pb2.GetOrdersReq) (*pb2.GetOrdersRes, error) {
-	customerId := req.GetCustomerId()
-	if len(customerId) > 0 {
-		fmt.Println("We have a customer id")
-		var orders []*entity.Order
-		parseId, _ := primitive.ObjectIDFromHex(req.GetCustomerId())
-		err := s.Store.GetAll(entity.OrderCollectionName, utils.KeyValue{"customerId": parseId}, &orders)
-		if err != nil {
-			logrus.Println("Failed to get all orders", err)
-		}
-		var pbOrders = make([]*pb2.Order, 0)
-
-		for _, order := range orders {
-			var products []*pb2.Product
-
-			for _, item := range order.Items {
-				products = append(products, &pb2.Product{
-					Id:          Ptr(item.ID.Hex()),
-					Price:       item.Price,
-					Name:        item.Name,
-					Description: &item.Description,
-					Sku:         item.SKU,
-				})
-			}
-
-			pbOrders = append(pbOrders, &pb2.Order{
-				Id:           Ptr(order.ID.Hex()),
-				Status:       order.Status,
-				Items:        products,
-				DeliveryDate: Ptr(int64(order.DeliveryDate.T)),
-				OrderNo:      order.OrderNo,
-				CustomerId:   order.CustomerId.Hex(),
-			})
-		}
-		return &pb2.GetOrdersRes{Orders: pbOrders}, nil
-	} else {
-		var orders []entity.Order
-		err := s.Store.GetAll(entity.OrderCollectionName, utils.KeyValue{}, &orders)
-		fmt.Println("Orders slice ", orders)
-		if err != nil {
-			return nil, err
-		}
-		var pbOrders = make([]*pb2.Order, 0)
-
-		for _, order := range orders {
-			var products = make([]*pb2.Product, 0)
-
-			for _, item := range order.Items {
-				products = append(products, &pb2.Product{
-					Id:          Ptr(item.ID.Hex()),
-					Price:       item.Price,
-					Name:        item.Name,
-					Description: &item.Description,
-					Sku:         item.SKU,
-				})
-			}
-
-			pbOrders = append(pbOrders, &pb2.Order{
-				Id:           Ptr(order.ID.Hex()),
-				Status:       order.Status,
-				Items:        products,
-				DeliveryDate: Ptr(int64(order.DeliveryDate.T)),
-				OrderNo:      order.OrderNo,
-				CustomerId:   order.CustomerId.Hex(),
-			})
-		}
-
-		return &pb2.GetOrdersRes{Orders: pbOrders}, nil
+	filter, query, err := ordersQuery(req)
+	if err != nil {
+		return nil, err
 	}
-}
 
-func (s *OrderServer) GetOrdersStream(req *pb2.EmptyReq, stream pb2.Orders_GetOrdersStreamServer) error {
-	orderStream, err := s.Store.Watch(entity.OrderCollectionName, 24*time.Hour)
+	orders, next, err := s.Orders.Find(filter, query)
+	if errors.Is(err, db.ErrInvalidPageToken) {
+		return nil, errs.NewFieldViolation("pageToken", "is invalid or belongs to a different query")
+	}
 	if err != nil {
-		return err
+		return nil, errs.Wrap(err, "failed to list orders")
+	}
+
+	var pbOrders = make([]*pb2.Order, 0, len(orders))
+	for _, order := range orders {
+		pbOrders = append(pbOrders, mapper.OrderToProto(order))
 	}
-	for orderStream.Next(stream.Context()) {
-		var event bson.M
-		err := orderStream.Decode(&event)
+	res := &pb2.GetOrdersRes{Orders: pbOrders, NextPageToken: next}
+
+	if req.GetIncludeTotal() {
+		total, err := s.Orders.Count(filter)
 		if err != nil {
-			logrus.Error("Error ", err)
-			return err
-		}
-		rv := reflect.ValueOf(event["operationType"])
-		opType, ok := rv.Interface().(string)
-		if !ok {
-			logrus.Error("String expected in operationType\n")
-			return nil
-		}
-		var o entity.Order
-
-		if opType == "insert" || opType == "update" {
-			logrus.Info("Document ", event["fullDocument"])
-			err := mapstructure.Decode(event["fullDocument"], &o)
-			if err != nil {
-				return err
-			}
+			return nil, errs.Wrap(err, "failed to count orders")
 		}
+		res.TotalCount = Ptr(total)
+	}
+	return res, nil
+}
 
-		var products []*pb2.Product
-		for _, item := range o.Items {
-			products = append(products, &pb2.Product{
-				Id:          Ptr(item.ID.Hex()),
-				Price:       item.Price,
-				Name:        item.Name,
-				Description: &item.Description,
-				Sku:         item.SKU,
-			})
-		}
-		protoOrder := &pb2.Order{
-			Id:           Ptr(o.ID.Hex()),
-			Status:       o.Status,
-			Items:        products,
-			DeliveryDate: Ptr(int64(o.DeliveryDate.T)),
-			OrderNo:      o.OrderNo,
-			CustomerId:   o.CustomerId.Hex(),
-		}
-		sErr := stream.Send(&pb2.GetOrderRes{Order: protoOrder})
+// GetOrder reads through the proto-<id> cache entries, falling back to the store and repopulating the cache on a miss.
+func (s *OrderServer) GetOrder(ctx context.Context, req *pb2.GetOrderReq) (*pb2.GetOrderRes, error) {
+	var violations validation.Violations
+	id := violations.ObjectID("id", req.GetId())
+	if err := violations.Err(); err != nil {
+		return nil, err
+	}
 
-		if sErr != nil {
-			return sErr
-		}
+	if cached := s.cachedOrder(id.Hex()); cached != nil {
+		return &pb2.GetOrderRes{Order: cached}, nil
 	}
 
-	defer func(orderStream *mongo.ChangeStream, ctx context.Context) {
-		err := orderStream.Close(ctx)
-		if err != nil {
-			stream.Context().Done()
-			logrus.Warning("Failed to close stream")
-		}
-	}(orderStream, s.Store.Context)
+	order, err := s.getOrder(id)
+	if err != nil {
+		return nil, err
+	}
 
-	return nil
+	protoOrder := mapper.OrderToProto(order)
+	s.cacheOrder(protoOrder)
+	return &pb2.GetOrderRes{Order: protoOrder}, nil
 }
 
+// CreateOrder places an order once per idempotency key.
 func (s *OrderServer) CreateOrder(ctx context.Context, req *pb2.CreateOrderReq) (*pb2.CreateOrderRes, error) {
+	return idempotency.Do(ctx, s.Idempotency, "CreateOrder", req, func() (*pb2.CreateOrderRes, error) {
+		return s.createOrder(req)
+	})
+}
+
+func (s *OrderServer) createOrder(req *pb2.CreateOrderReq) (*pb2.CreateOrderRes, error) {
 	newOrder := entity.NewOrder()
 	reqOrder := req.GetOrder()
-
-	newOrder.ID = primitive.NewObjectID()
-	newOrder.OrderNo = reqOrder.GetOrderNo()
-	newOrder.Status = reqOrder.GetStatus()
-	newOrder.CustomerId, _ = primitive.ObjectIDFromHex(reqOrder.GetCustomerId())
-	newOrder.DeliveryDate = primitive.Timestamp{
-		T: uint32(time.UnixMilli(reqOrder.GetDeliveryDate()).Unix()),
-		I: 0,
-	}
-	var products = make([]entity.Product, 0)
-	for _, item := range reqOrder.Items {
-		newProduct := entity.NewProduct()
-		newProduct.ID = primitive.NewObjectID()
-		newProduct.Price = item.GetPrice()
-		newProduct.Name = item.GetName()
-		newProduct.SKU = item.GetSku()
-		newProduct.Description = item.GetDescription()
-		newProduct.CreatedAt = primitive.Timestamp{
-			T: uint32(time.Now().Unix()),
-			I: 0,
-		}
-		products = append(products, newProduct)
+	if reqOrder == nil {
+		return nil, errs.NewFieldViolation("order", "is required")
 	}
 
-	newOrder.Items = products
-	_, err := newOrder.Persist(s.Store)
+	var violations validation.Violations
+	// Every order starts as processing and then follows the transition table.
+	if st := reqOrder.GetStatus(); st != pb2.OrderStatus_ORDER_STATUS_UNSPECIFIED && st != pb2.OrderStatus_ORDER_STATUS_PROCESSING {
+		violations.Add("order.status", "new orders start as %s, got %s", pb2.OrderStatus_ORDER_STATUS_PROCESSING, st)
+	}
 
+	newOrder.ID = primitive.NewObjectID()
+	newOrder.Status = entity.Processing
+	newOrder.CustomerId = violations.ObjectID("order.customerId", reqOrder.GetCustomerId())
+	newOrder.DeliveryDate = violations.Time("order.deliveryDate", reqOrder.GetDeliveryDate())
+	lines, err := s.linesFromCatalog(reqOrder.GetLines(), &violations)
 	if err != nil {
-		logrus.Warning("Error persisting the order")
+		return nil, err
+	}
+	newOrder.Items = lines
+	err = newOrder.ComputeTotals(s.Pricing)
+	if errors.Is(err, money.ErrOverflow) {
+		return nil, errs.NewFieldViolation("order.lines", "the order total is too large")
 	}
-
 	if err != nil {
-		fmt.Println("Error creating a new Order", err)
+		return nil, errs.Wrap(err, "failed to compute order totals")
 	}
-
-	var items []*pb2.Product
-	for _, item := range newOrder.Items {
-		items = append(items, &pb2.Product{
-			Id:    Ptr(item.ID.Hex()),
-			Price: item.Price,
-
-			Name:        item.Name,
-			Description: &item.Description,
-		})
+	violations.Struct("order", newOrder)
+	if err := violations.Err(); err != nil {
+		return nil, err
 	}
 
-	protoOrder := &pb2.Order{
-		Id:           Ptr(newOrder.ID.Hex()),
-		Status:       newOrder.Status,
-		CustomerId:   newOrder.CustomerId.Hex(),
-		OrderNo:      newOrder.OrderNo,
-		Items:        items,
-		DeliveryDate: Ptr(time.UnixMilli(int64(newOrder.DeliveryDate.T)).UnixMilli()),
+	// Numbers are only drawn for valid orders so rejected requests do not
+	// leave gaps in the sequence.
+	orderNo, err := s.Numbers.Next(time.Now().UTC())
+	if err != nil {
+		return nil, errs.Wrap(err, "failed to number order")
 	}
-
-	go func(p *pb2.Order) {
-		b, mErr := proto.Marshal(protoOrder)
-		if mErr != nil {
-			logrus.Warning("Error marshalling proto", mErr)
-		}
-		//byteStr := string(b)
-		//newSlice = append(currentOrders, [b]...);
-		cacheErr := s.Cache.Set(fmt.Sprintf("proto-%s", p.GetId()), b, 24*time.Hour)
-		if cacheErr != nil {
-			logrus.Warning("Cache error ", cacheErr)
+	newOrder.OrderNo = orderNo
+	err = s.Transaction(func(tx *repository.Repositories) error {
+		if _, err := newOrder.Persist(tx.Orders); err != nil {
+			return err
 		}
-	}(protoOrder)
+		return recordEvent(tx, events.Created, newOrder, "")
+	})
+	if errors.Is(err, db.ErrDuplicateKey) {
+		return nil, errs.NewConflict("order number %s is already taken", orderNo)
+	}
+	// errs.ToStatus maps the other storage errors to their codes.
+	if err != nil {
+		return nil, err
+	}
 
-	return &pb2.CreateOrderRes{Order: protoOrder}, err
+	return &pb2.CreateOrderRes{Order: mapper.OrderToProto(newOrder)}, nil
 }
 
-func (s *OrderServer) CreateCustomer(ctx context.Context, req *pb2.CreateCustomerReq) (*pb2.CreateCustomerRes, error) {
-	s.Log.Println("Creating a new customer ", req.GetLat())
-	validate := validator.New()
-	newCustomer := entity.NewCustomer()
-	fmt.Println("Customer ", req.GetName())
-
-	newCustomer.Name = req.GetName()
-	p := entity.Location{
-		Lat:  req.GetLat(),
-		Long: req.GetLon(),
+// GetOrderByNumber looks an order up by the number CreateOrder assigned.
+func (s *OrderServer) GetOrderByNumber(ctx context.Context, req *pb2.GetOrderByNumberReq) (*pb2.GetOrderRes, error) {
+	orderNo := strings.TrimSpace(req.GetOrderNo())
+	if orderNo == "" {
+		return nil, errs.NewFieldViolation("orderNo", "is required")
 	}
 
-	newCustomer.Position = p
-	err := validate.Struct(newCustomer)
+	order, err := entity.NewOrder().Get(s.Orders, utils.KeyValue{"orderNo": orderNo})
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, errs.NewNotFound("order %s not found", orderNo)
+	}
+	if err != nil {
+		return nil, errs.Wrap(err, "failed to get order")
+	}
+	return &pb2.GetOrderRes{Order: mapper.OrderToProto(order)}, nil
+}
 
+// UpdateOrder replaces the items, delivery date and customer of an open
+// order. Status changes go through UpdateOrderStatus; one that lands while
+// the order is being updated aborts the update.
+func (s *OrderServer) UpdateOrder(ctx context.Context, req *pb2.UpdateOrderReq) (*pb2.UpdateOrderRes, error) {
+	reqOrder := req.GetOrder()
+	if reqOrder == nil {
+		return nil, errs.NewFieldViolation("order", "is required")
+	}
+	var violations validation.Violations
+	id := violations.ObjectID("order.id", reqOrder.GetId())
+	update := entity.NewOrder()
+	update.Status = entity.Processing
+	update.CustomerId = violations.ObjectID("order.customerId", reqOrder.GetCustomerId())
+	update.DeliveryDate = violations.Time("order.deliveryDate", reqOrder.GetDeliveryDate())
+	lines, err := s.linesFromCatalog(reqOrder.GetLines(), &violations)
 	if err != nil {
-		return &pb2.CreateCustomerRes{
-			Success: false,
-			Message: err.Error(),
-		}, err
+		return nil, err
+	}
+	update.Items = lines
+	err = update.ComputeTotals(s.Pricing)
+	if errors.Is(err, money.ErrOverflow) {
+		return nil, errs.NewFieldViolation("order.lines", "the order total is too large")
+	}
+	if err != nil {
+		return nil, errs.Wrap(err, "failed to compute order totals")
+	}
+	violations.Struct("order", update)
+	if err := violations.Err(); err != nil {
+		return nil, err
 	}
 
-	insertErr := s.Store.Insert(entity.CustomerCollectionName, newCustomer)
+	order, err := s.getOrder(id)
+	if err != nil {
+		return nil, err
+	}
+	if order.IsClosed() {
+		return nil, errs.NewFailedPrecondition("order %s is %s and can no longer be updated", id.Hex(), order.Status)
+	}
 
-	if insertErr != nil {
-		s.Log.Println("Failed to create a new customer")
-		return nil, insertErr
+	order.CustomerId = update.CustomerId
+	order.DeliveryDate = update.DeliveryDate
+	order.Items = update.Items
+	order.Subtotal = update.Subtotal
+	order.Tax = update.Tax
+	order.Shipping = update.Shipping
+	order.Total = update.Total
+	if order.CreatedAt.IsZero() {
+		// Orders created before CreatedAt was set would otherwise be
+		// inserted again instead of replaced.
+		order.CreatedAt = order.ID.Timestamp()
+	}
+	err = s.Transaction(func(tx *repository.Repositories) error {
+		if _, err := order.Persist(tx.Orders); err != nil {
+			return err
+		}
+		return recordEvent(tx, events.Updated, order, "")
+	})
+	// errs.ToStatus maps concurrency and storage errors to their codes.
+	if err != nil {
+		return nil, err
 	}
+	s.invalidateOrder(id.Hex())
 
-	return &pb2.CreateCustomerRes{
-		Success: true,
-		Message: "Customer created Successfully ",
-	}, nil
+	return &pb2.UpdateOrderRes{Order: mapper.OrderToProto(order)}, nil
+}
+
+// linesFromCatalog resolves the requested lines against the product
+// catalog by id, or by SKU when no id is given, and snapshots them into
+// order lines. Unknown, archived and foreign currency products are
+// recorded as violations.
+func (s *OrderServer) linesFromCatalog(reqLines []*pb2.OrderLine, violations *validation.Violations) ([]entity.OrderLine, error) {
+	var lines = make([]entity.OrderLine, 0, len(reqLines))
+	for i, reqLine := range reqLines {
+		field := fmt.Sprintf("order.lines[%d]", i)
+		var filter utils.KeyValue
+		switch {
+		case reqLine.GetProductId() != "":
+			filter = utils.KeyValue{"_id": violations.ObjectID(field+".productId", reqLine.GetProductId())}
+		case reqLine.GetSku() != "":
+			filter = utils.KeyValue{"sku": reqLine.GetSku()}
+		default:
+			violations.Add(field, "needs a productId or sku")
+			continue
+		}
+
+		product := entity.NewProduct()
+		_, err := product.GetProduct(s.Products, filter)
+		switch {
+		case errors.Is(err, db.ErrNotFound):
+			violations.Add(field, "no catalog product with id %q or sku %q", reqLine.GetProductId(), reqLine.GetSku())
+		case err != nil:
+			return nil, errs.Wrap(err, "failed to look up products")
+		case product.Archived:
+			violations.Add(field, "product %s is archived", product.ID.Hex())
+		case product.Price.Currency != s.Pricing.Currency:
+			violations.Add(field, "product %s is priced in %q, orders are in %s", product.ID.Hex(), product.Price.Currency, s.Pricing.Currency)
+		default:
+			lines = append(lines, product.Line(reqLine.GetQuantity()))
+		}
+	}
+	return lines, nil
 }
 
 func (s *OrderServer) UpdateOrderStatus(ctx context.Context, req *pb2.UpdateOrderStatusReq) (*pb2.UpdateOrderStatusRes, error) {
 	orderId := req.GetId()
-	logrus.Info("We got called ", req.GetId())
-	m, err := entity.NewOrder().UpdateOne(s.Store, orderId, req.GetStatus())
-	logrus.Warning("Matched count ", m)
+	var violations validation.Violations
+	id := violations.ObjectID("id", orderId)
+	next, ok := mapper.StatusFromProto(req.GetStatus())
+	if !ok {
+		violations.Add("status", "%s is not a valid status", req.GetStatus())
+	}
+	if err := violations.Err(); err != nil {
+		return nil, err
+	}
+
+	order, err := s.getOrder(id)
 	if err != nil {
-		logrus.Println("We failed getting order", err)
 		return nil, err
 	}
 
+	previous := order.Status
+	err = s.Transaction(func(tx *repository.Repositories) error {
+		if err := order.UpdateStatus(tx.Orders, next); err != nil {
+			return err
+		}
+		return recordEvent(tx, events.StatusChanged, order, previous)
+	})
+	// errs.ToStatus maps transition and concurrency errors to their codes.
+	if err != nil {
+		return nil, err
+	}
+	s.invalidateOrder(id.Hex())
+
 	return &pb2.UpdateOrderStatusRes{
 		Success: true,
 		Message: "Update Successful",
 	}, nil
 }
 
+// getOrder loads an order by id, reporting a missing one as not found.
+func (s *OrderServer) getOrder(id primitive.ObjectID) (*entity.Order, error) {
+	order, err := entity.NewOrder().Get(s.Orders, utils.KeyValue{"_id": id})
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, errs.NewNotFound("order %s not found", id.Hex())
+	}
+	if err != nil {
+		return nil, errs.Wrap(err, "failed to get order")
+	}
+	return order, nil
+}
+
+// recordEvent writes the outbox message of an order change in tx.
+func recordEvent(tx *repository.Repositories, eventType events.Type, order *entity.Order, previous entity.OrderStatus) error {
+	record, err := outbox.OrderRecord(eventType, order, previous)
+	if err != nil {
+		return err
+	}
+	return tx.Outbox.Insert(record)
+}
+
 func (s *OrderServer) MustEmbedUnimplementedOrdersServer() {}
 
-func NewOrderServer(log *logrus.Logger, mongo *db.MongoStore, redisCache *cache.RedisCache) *OrderServer {
+func NewOrderServer(log *logrus.Logger, repos *repository.Repositories, redisCache cache.ICache, pricing entity.Pricing, numbers *orderno.Generator, broadcaster *events.Broadcaster, idempotent *idempotency.Store) *OrderServer {
 	return &OrderServer{
 		UnimplementedOrdersServer: pb2.UnimplementedOrdersServer{},
 		Log:                       log,
-		Store:                     mongo,
+		Orders:                    repos.Orders,
+		Customers:                 repos.Customers,
+		Products:                  repos.Products,
 		Cache:                     redisCache,
+		Pricing:                   pricing,
+		Numbers:                   numbers,
+		Events:                    broadcaster,
+		Transaction:               repos.Transaction,
+		Idempotency:               idempotent,
 	}
 }
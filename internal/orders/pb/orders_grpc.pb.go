@@ -23,10 +23,21 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type OrdersClient interface {
 	GetOrders(ctx context.Context, in *GetOrdersReq, opts ...grpc.CallOption) (*GetOrdersRes, error)
-	GetOrdersStream(ctx context.Context, in *EmptyReq, opts ...grpc.CallOption) (Orders_GetOrdersStreamClient, error)
+	// GetOrdersStream is superseded by StreamOrderEvents; it sends the order
+	// of every change except deletes.
+	GetOrdersStream(ctx context.Context, in *GetOrdersStreamReq, opts ...grpc.CallOption) (Orders_GetOrdersStreamClient, error)
+	StreamOrderEvents(ctx context.Context, in *StreamOrderEventsReq, opts ...grpc.CallOption) (Orders_StreamOrderEventsClient, error)
+	GetOrder(ctx context.Context, in *GetOrderReq, opts ...grpc.CallOption) (*GetOrderRes, error)
+	GetOrderByNumber(ctx context.Context, in *GetOrderByNumberReq, opts ...grpc.CallOption) (*GetOrderRes, error)
 	CreateOrder(ctx context.Context, in *CreateOrderReq, opts ...grpc.CallOption) (*CreateOrderRes, error)
 	CreateCustomer(ctx context.Context, in *CreateCustomerReq, opts ...grpc.CallOption) (*CreateCustomerRes, error)
 	UpdateOrderStatus(ctx context.Context, in *UpdateOrderStatusReq, opts ...grpc.CallOption) (*UpdateOrderStatusRes, error)
+	UpdateOrder(ctx context.Context, in *UpdateOrderReq, opts ...grpc.CallOption) (*UpdateOrderRes, error)
+	GetCustomer(ctx context.Context, in *GetCustomerReq, opts ...grpc.CallOption) (*GetCustomerRes, error)
+	ListCustomers(ctx context.Context, in *ListCustomersReq, opts ...grpc.CallOption) (*ListCustomersRes, error)
+	UpdateCustomer(ctx context.Context, in *UpdateCustomerReq, opts ...grpc.CallOption) (*UpdateCustomerRes, error)
+	// DeleteCustomer refuses customers that still have open orders.
+	DeleteCustomer(ctx context.Context, in *DeleteCustomerReq, opts ...grpc.CallOption) (*DeleteCustomerRes, error)
 }
 
 type ordersClient struct {
@@ -46,7 +57,7 @@ func (c *ordersClient) GetOrders(ctx context.Context, in *GetOrdersReq, opts ...
 	return out, nil
 }
 
-func (c *ordersClient) GetOrdersStream(ctx context.Context, in *EmptyReq, opts ...grpc.CallOption) (Orders_GetOrdersStreamClient, error) {
+func (c *ordersClient) GetOrdersStream(ctx context.Context, in *GetOrdersStreamReq, opts ...grpc.CallOption) (Orders_GetOrdersStreamClient, error) {
 	stream, err := c.cc.NewStream(ctx, &Orders_ServiceDesc.Streams[0], "/Orders/GetOrdersStream", opts...)
 	if err != nil {
 		return nil, err
@@ -62,7 +73,7 @@ func (c *ordersClient) GetOrdersStream(ctx context.Context, in *EmptyReq, opts .
 }
 
 type Orders_GetOrdersStreamClient interface {
-	Recv() (*GetOrderRes, error)
+	Recv() (*OrderStreamRes, error)
 	grpc.ClientStream
 }
 
@@ -70,14 +81,64 @@ type ordersGetOrdersStreamClient struct {
 	grpc.ClientStream
 }
 
-func (x *ordersGetOrdersStreamClient) Recv() (*GetOrderRes, error) {
-	m := new(GetOrderRes)
+func (x *ordersGetOrdersStreamClient) Recv() (*OrderStreamRes, error) {
+	m := new(OrderStreamRes)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
+func (c *ordersClient) StreamOrderEvents(ctx context.Context, in *StreamOrderEventsReq, opts ...grpc.CallOption) (Orders_StreamOrderEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Orders_ServiceDesc.Streams[1], "/Orders/StreamOrderEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &ordersStreamOrderEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Orders_StreamOrderEventsClient interface {
+	Recv() (*OrderEvent, error)
+	grpc.ClientStream
+}
+
+type ordersStreamOrderEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *ordersStreamOrderEventsClient) Recv() (*OrderEvent, error) {
+	m := new(OrderEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *ordersClient) GetOrder(ctx context.Context, in *GetOrderReq, opts ...grpc.CallOption) (*GetOrderRes, error) {
+	out := new(GetOrderRes)
+	err := c.cc.Invoke(ctx, "/Orders/GetOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ordersClient) GetOrderByNumber(ctx context.Context, in *GetOrderByNumberReq, opts ...grpc.CallOption) (*GetOrderRes, error) {
+	out := new(GetOrderRes)
+	err := c.cc.Invoke(ctx, "/Orders/GetOrderByNumber", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *ordersClient) CreateOrder(ctx context.Context, in *CreateOrderReq, opts ...grpc.CallOption) (*CreateOrderRes, error) {
 	out := new(CreateOrderRes)
 	err := c.cc.Invoke(ctx, "/Orders/CreateOrder", in, out, opts...)
@@ -105,15 +166,71 @@ func (c *ordersClient) UpdateOrderStatus(ctx context.Context, in *UpdateOrderSta
 	return out, nil
 }
 
+func (c *ordersClient) UpdateOrder(ctx context.Context, in *UpdateOrderReq, opts ...grpc.CallOption) (*UpdateOrderRes, error) {
+	out := new(UpdateOrderRes)
+	err := c.cc.Invoke(ctx, "/Orders/UpdateOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ordersClient) GetCustomer(ctx context.Context, in *GetCustomerReq, opts ...grpc.CallOption) (*GetCustomerRes, error) {
+	out := new(GetCustomerRes)
+	err := c.cc.Invoke(ctx, "/Orders/GetCustomer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ordersClient) ListCustomers(ctx context.Context, in *ListCustomersReq, opts ...grpc.CallOption) (*ListCustomersRes, error) {
+	out := new(ListCustomersRes)
+	err := c.cc.Invoke(ctx, "/Orders/ListCustomers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ordersClient) UpdateCustomer(ctx context.Context, in *UpdateCustomerReq, opts ...grpc.CallOption) (*UpdateCustomerRes, error) {
+	out := new(UpdateCustomerRes)
+	err := c.cc.Invoke(ctx, "/Orders/UpdateCustomer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ordersClient) DeleteCustomer(ctx context.Context, in *DeleteCustomerReq, opts ...grpc.CallOption) (*DeleteCustomerRes, error) {
+	out := new(DeleteCustomerRes)
+	err := c.cc.Invoke(ctx, "/Orders/DeleteCustomer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OrdersServer is the server API for Orders service.
 // All implementations must embed UnimplementedOrdersServer
 // for forward compatibility
 type OrdersServer interface {
 	GetOrders(context.Context, *GetOrdersReq) (*GetOrdersRes, error)
-	GetOrdersStream(*EmptyReq, Orders_GetOrdersStreamServer) error
+	// GetOrdersStream is superseded by StreamOrderEvents; it sends the order
+	// of every change except deletes.
+	GetOrdersStream(*GetOrdersStreamReq, Orders_GetOrdersStreamServer) error
+	StreamOrderEvents(*StreamOrderEventsReq, Orders_StreamOrderEventsServer) error
+	GetOrder(context.Context, *GetOrderReq) (*GetOrderRes, error)
+	GetOrderByNumber(context.Context, *GetOrderByNumberReq) (*GetOrderRes, error)
 	CreateOrder(context.Context, *CreateOrderReq) (*CreateOrderRes, error)
 	CreateCustomer(context.Context, *CreateCustomerReq) (*CreateCustomerRes, error)
 	UpdateOrderStatus(context.Context, *UpdateOrderStatusReq) (*UpdateOrderStatusRes, error)
+	UpdateOrder(context.Context, *UpdateOrderReq) (*UpdateOrderRes, error)
+	GetCustomer(context.Context, *GetCustomerReq) (*GetCustomerRes, error)
+	ListCustomers(context.Context, *ListCustomersReq) (*ListCustomersRes, error)
+	UpdateCustomer(context.Context, *UpdateCustomerReq) (*UpdateCustomerRes, error)
+	// DeleteCustomer refuses customers that still have open orders.
+	DeleteCustomer(context.Context, *DeleteCustomerReq) (*DeleteCustomerRes, error)
 	mustEmbedUnimplementedOrdersServer()
 }
 
@@ -124,9 +241,18 @@ type UnimplementedOrdersServer struct {
 func (UnimplementedOrdersServer) GetOrders(context.Context, *GetOrdersReq) (*GetOrdersRes, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetOrders not implemented")
 }
-func (UnimplementedOrdersServer) GetOrdersStream(*EmptyReq, Orders_GetOrdersStreamServer) error {
+func (UnimplementedOrdersServer) GetOrdersStream(*GetOrdersStreamReq, Orders_GetOrdersStreamServer) error {
 	return status.Errorf(codes.Unimplemented, "method GetOrdersStream not implemented")
 }
+func (UnimplementedOrdersServer) StreamOrderEvents(*StreamOrderEventsReq, Orders_StreamOrderEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamOrderEvents not implemented")
+}
+func (UnimplementedOrdersServer) GetOrder(context.Context, *GetOrderReq) (*GetOrderRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrder not implemented")
+}
+func (UnimplementedOrdersServer) GetOrderByNumber(context.Context, *GetOrderByNumberReq) (*GetOrderRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrderByNumber not implemented")
+}
 func (UnimplementedOrdersServer) CreateOrder(context.Context, *CreateOrderReq) (*CreateOrderRes, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateOrder not implemented")
 }
@@ -136,6 +262,21 @@ func (UnimplementedOrdersServer) CreateCustomer(context.Context, *CreateCustomer
 func (UnimplementedOrdersServer) UpdateOrderStatus(context.Context, *UpdateOrderStatusReq) (*UpdateOrderStatusRes, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateOrderStatus not implemented")
 }
+func (UnimplementedOrdersServer) UpdateOrder(context.Context, *UpdateOrderReq) (*UpdateOrderRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateOrder not implemented")
+}
+func (UnimplementedOrdersServer) GetCustomer(context.Context, *GetCustomerReq) (*GetCustomerRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCustomer not implemented")
+}
+func (UnimplementedOrdersServer) ListCustomers(context.Context, *ListCustomersReq) (*ListCustomersRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCustomers not implemented")
+}
+func (UnimplementedOrdersServer) UpdateCustomer(context.Context, *UpdateCustomerReq) (*UpdateCustomerRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCustomer not implemented")
+}
+func (UnimplementedOrdersServer) DeleteCustomer(context.Context, *DeleteCustomerReq) (*DeleteCustomerRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCustomer not implemented")
+}
 func (UnimplementedOrdersServer) mustEmbedUnimplementedOrdersServer() {}
 
 // UnsafeOrdersServer may be embedded to opt out of forward compatibility for this service.
@@ -168,7 +309,7 @@ func _Orders_GetOrders_Handler(srv interface{}, ctx context.Context, dec func(in
 }
 
 func _Orders_GetOrdersStream_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(EmptyReq)
+	m := new(GetOrdersStreamReq)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
@@ -176,7 +317,7 @@ func _Orders_GetOrdersStream_Handler(srv interface{}, stream grpc.ServerStream)
 }
 
 type Orders_GetOrdersStreamServer interface {
-	Send(*GetOrderRes) error
+	Send(*OrderStreamRes) error
 	grpc.ServerStream
 }
 
@@ -184,10 +325,67 @@ type ordersGetOrdersStreamServer struct {
 	grpc.ServerStream
 }
 
-func (x *ordersGetOrdersStreamServer) Send(m *GetOrderRes) error {
+func (x *ordersGetOrdersStreamServer) Send(m *OrderStreamRes) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Orders_StreamOrderEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamOrderEventsReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OrdersServer).StreamOrderEvents(m, &ordersStreamOrderEventsServer{stream})
+}
+
+type Orders_StreamOrderEventsServer interface {
+	Send(*OrderEvent) error
+	grpc.ServerStream
+}
+
+type ordersStreamOrderEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *ordersStreamOrderEventsServer) Send(m *OrderEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Orders_GetOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrderReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersServer).GetOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Orders/GetOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersServer).GetOrder(ctx, req.(*GetOrderReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Orders_GetOrderByNumber_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrderByNumberReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersServer).GetOrderByNumber(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Orders/GetOrderByNumber",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersServer).GetOrderByNumber(ctx, req.(*GetOrderByNumberReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Orders_CreateOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateOrderReq)
 	if err := dec(in); err != nil {
@@ -242,6 +440,96 @@ func _Orders_UpdateOrderStatus_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Orders_UpdateOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateOrderReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersServer).UpdateOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Orders/UpdateOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersServer).UpdateOrder(ctx, req.(*UpdateOrderReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Orders_GetCustomer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCustomerReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersServer).GetCustomer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Orders/GetCustomer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersServer).GetCustomer(ctx, req.(*GetCustomerReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Orders_ListCustomers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCustomersReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersServer).ListCustomers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Orders/ListCustomers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersServer).ListCustomers(ctx, req.(*ListCustomersReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Orders_UpdateCustomer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCustomerReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersServer).UpdateCustomer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Orders/UpdateCustomer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersServer).UpdateCustomer(ctx, req.(*UpdateCustomerReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Orders_DeleteCustomer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCustomerReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersServer).DeleteCustomer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Orders/DeleteCustomer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersServer).DeleteCustomer(ctx, req.(*DeleteCustomerReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Orders_ServiceDesc is the grpc.ServiceDesc for Orders service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -253,6 +541,14 @@ var Orders_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetOrders",
 			Handler:    _Orders_GetOrders_Handler,
 		},
+		{
+			MethodName: "GetOrder",
+			Handler:    _Orders_GetOrder_Handler,
+		},
+		{
+			MethodName: "GetOrderByNumber",
+			Handler:    _Orders_GetOrderByNumber_Handler,
+		},
 		{
 			MethodName: "CreateOrder",
 			Handler:    _Orders_CreateOrder_Handler,
@@ -265,6 +561,26 @@ var Orders_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateOrderStatus",
 			Handler:    _Orders_UpdateOrderStatus_Handler,
 		},
+		{
+			MethodName: "UpdateOrder",
+			Handler:    _Orders_UpdateOrder_Handler,
+		},
+		{
+			MethodName: "GetCustomer",
+			Handler:    _Orders_GetCustomer_Handler,
+		},
+		{
+			MethodName: "ListCustomers",
+			Handler:    _Orders_ListCustomers_Handler,
+		},
+		{
+			MethodName: "UpdateCustomer",
+			Handler:    _Orders_UpdateCustomer_Handler,
+		},
+		{
+			MethodName: "DeleteCustomer",
+			Handler:    _Orders_DeleteCustomer_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -272,6 +588,11 @@ var Orders_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Orders_GetOrdersStream_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "StreamOrderEvents",
+			Handler:       _Orders_StreamOrderEvents_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "orders.proto",
 }
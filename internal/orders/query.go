@@ -0,0 +1,105 @@
+package orders
+
+import (
+	"awesomeProject/internal/mapper"
+	pb2 "awesomeProject/internal/orders/pb"
+	"awesomeProject/internal/validation"
+	"awesomeProject/pkg/db"
+	"awesomeProject/pkg/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"time"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 500
+)
+
+var orderSortFields = map[pb2.OrderSortField]string{
+	pb2.OrderSortField_ORDER_SORT_FIELD_UNSPECIFIED:   "createdAt",
+	pb2.OrderSortField_ORDER_SORT_FIELD_CREATED_AT:    "createdAt",
+	pb2.OrderSortField_ORDER_SORT_FIELD_DELIVERY_DATE: "deliveryDate",
+	pb2.OrderSortField_ORDER_SORT_FIELD_ORDER_NO:      "orderNo",
+	pb2.OrderSortField_ORDER_SORT_FIELD_STATUS:        "status",
+}
+
+// ordersQuery turns a GetOrdersReq into a store filter and page query,
+// reporting every invalid field at once.
+func ordersQuery(req *pb2.GetOrdersReq) (utils.KeyValue, db.Query, error) {
+	var violations validation.Violations
+	filter := utils.KeyValue{}
+	if req.CustomerId != nil {
+		filter["customerId"] = violations.ObjectID("customerId", req.GetCustomerId())
+	}
+
+	if len(req.GetStatuses()) > 0 {
+		statuses := bson.A{}
+		for _, st := range req.GetStatuses() {
+			stored, ok := mapper.StatusFromProto(st)
+			if !ok {
+				violations.Add("statuses", "%s is not a valid status", st)
+			}
+			statuses = append(statuses, stored)
+		}
+		filter["status"] = bson.M{"$in": statuses}
+	}
+
+	deliveryFrom := violations.Time("deliveryDateFrom", req.GetDeliveryDateFrom())
+	deliveryTo := violations.Time("deliveryDateTo", req.GetDeliveryDateTo())
+	if !deliveryFrom.IsZero() && !deliveryTo.IsZero() && deliveryTo.Before(deliveryFrom) {
+		violations.Add("deliveryDateTo", "must not be before deliveryDateFrom")
+	}
+	createdFrom := violations.Time("createdFrom", req.GetCreatedFrom())
+	createdTo := violations.Time("createdTo", req.GetCreatedTo())
+	if !createdFrom.IsZero() && !createdTo.IsZero() && createdTo.Before(createdFrom) {
+		violations.Add("createdTo", "must not be before createdFrom")
+	}
+	if r := timeRange(deliveryFrom, deliveryTo); r != nil {
+		filter["deliveryDate"] = r
+	}
+	if r := timeRange(createdFrom, createdTo); r != nil {
+		filter["createdAt"] = r
+	}
+
+	sortField, ok := orderSortFields[req.GetSortBy()]
+	if !ok {
+		violations.Add("sortBy", "%s is not a sortable field", req.GetSortBy())
+	}
+	if _, ok := pb2.SortDirection_name[int32(req.GetSortDirection())]; !ok {
+		violations.Add("sortDirection", "%s is not a sort direction", req.GetSortDirection())
+	}
+	pageSize := int64(req.GetPageSize())
+	switch {
+	case pageSize < 0:
+		violations.Add("pageSize", "must not be negative")
+	case pageSize == 0:
+		pageSize = defaultPageSize
+	case pageSize > maxPageSize:
+		pageSize = maxPageSize
+	}
+
+	if err := violations.Err(); err != nil {
+		return nil, db.Query{}, err
+	}
+	return filter, db.Query{
+		Sort:       sortField,
+		Descending: req.GetSortDirection() == pb2.SortDirection_SORT_DIRECTION_DESC,
+		Limit:      pageSize,
+		PageToken:  req.GetPageToken(),
+	}, nil
+}
+
+// timeRange builds a [from, to) condition, leaving out zero bounds.
+func timeRange(from time.Time, to time.Time) bson.M {
+	if from.IsZero() && to.IsZero() {
+		return nil
+	}
+	r := bson.M{}
+	if !from.IsZero() {
+		r["$gte"] = from
+	}
+	if !to.IsZero() {
+		r["$lt"] = to
+	}
+	return r
+}
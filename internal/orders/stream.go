@@ -0,0 +1,177 @@
+package orders
+
+import (
+	"awesomeProject/internal/entity"
+	"awesomeProject/internal/errs"
+	"awesomeProject/internal/events"
+	"awesomeProject/internal/mapper"
+	pb2 "awesomeProject/internal/orders/pb"
+	"awesomeProject/internal/validation"
+	"awesomeProject/pkg/db"
+	"awesomeProject/pkg/utils"
+	"context"
+	"errors"
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// snapshotPage is the number of orders read at a time for a snapshot.
+const snapshotPage = 100
+
+// GetOrdersStream sends the order of every change until the client goes
+// away. All streams share one change stream through the broadcaster; a
+// client that falls too far behind is dropped with RESOURCE_EXHAUSTED under
+// the disconnect policy. A client resuming with a token the broadcaster no
+// longer remembers gets a snapshot of all orders, then the changes.
+func (s *OrderServer) GetOrdersStream(req *pb2.GetOrdersStreamReq, stream pb2.Orders_GetOrdersStreamServer) error {
+	sub, snapshot, err := s.subscribe(req.GetResumeToken(), events.Filter{})
+	if err != nil {
+		return err
+	}
+	defer sub.Close()
+
+	if snapshot {
+		err := s.eachSnapshot(utils.KeyValue{}, func(order *entity.Order) error {
+			return stream.Send(&pb2.OrderStreamRes{Order: mapper.OrderToProto(order), Snapshot: true})
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return forward(stream.Context(), sub, func(event events.Event) error {
+		if event.Order == nil {
+			// Deletes carry no order to send.
+			return nil
+		}
+		return stream.Send(&pb2.OrderStreamRes{Order: mapper.OrderToProto(event.Order), ResumeToken: event.Token})
+	})
+}
+
+// StreamOrderEvents sends the changes to the orders matching the request
+// filters, typed and with the status transition, until the client goes
+// away. It resumes like GetOrdersStream, with a snapshot of the matching
+// orders when the token has expired.
+func (s *OrderServer) StreamOrderEvents(req *pb2.StreamOrderEventsReq, stream pb2.Orders_StreamOrderEventsServer) error {
+	filter, err := eventFilter(req)
+	if err != nil {
+		return err
+	}
+	sub, snapshot, err := s.subscribe(req.GetResumeToken(), filter)
+	if err != nil {
+		return err
+	}
+	defer sub.Close()
+
+	if snapshot {
+		err := s.eachSnapshot(snapshotFilter(filter), func(order *entity.Order) error {
+			return stream.Send(mapper.OrderSnapshotToProto(order))
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return forward(stream.Context(), sub, func(event events.Event) error {
+		return stream.Send(mapper.OrderEventToProto(event))
+	})
+}
+
+func eventFilter(req *pb2.StreamOrderEventsReq) (events.Filter, error) {
+	var violations validation.Violations
+	var filter events.Filter
+	if req.GetCustomerId() != "" {
+		filter.CustomerID = violations.ObjectID("customerId", req.GetCustomerId())
+	}
+	for _, st := range req.GetStatuses() {
+		stored, ok := mapper.StatusFromProto(st)
+		if !ok {
+			violations.Add("statuses", "%s is not a valid status", st)
+		}
+		filter.Statuses = append(filter.Statuses, stored)
+	}
+	for i, hex := range req.GetOrderIds() {
+		filter.OrderIDs = append(filter.OrderIDs, violations.ObjectID(fmt.Sprintf("orderIds[%d]", i), hex))
+	}
+	return filter, violations.Err()
+}
+
+// snapshotFilter selects the stored orders an event filter matches now.
+func snapshotFilter(filter events.Filter) utils.KeyValue {
+	query := utils.KeyValue{}
+	if !filter.CustomerID.IsZero() {
+		query["customerId"] = filter.CustomerID
+	}
+	if len(filter.Statuses) > 0 {
+		statuses := bson.A{}
+		for _, status := range filter.Statuses {
+			statuses = append(statuses, status)
+		}
+		query["status"] = bson.M{"$in": statuses}
+	}
+	if len(filter.OrderIDs) > 0 {
+		ids := bson.A{}
+		for _, id := range filter.OrderIDs {
+			ids = append(ids, id)
+		}
+		query["_id"] = bson.M{"$in": ids}
+	}
+	return query
+}
+
+// subscribe resumes after token, or, when the token has expired, subscribes
+// from now on and reports that the caller must send a snapshot first.
+// Subscribing before the snapshot is read leaves no gap between the two.
+func (s *OrderServer) subscribe(token string, filter events.Filter) (*events.Subscription, bool, error) {
+	sub, err := s.Events.Subscribe(token, filter)
+	if errors.Is(err, events.ErrTokenExpired) {
+		sub, err = s.Events.Subscribe("", filter)
+		if err == nil {
+			return sub, true, nil
+		}
+	}
+	if err != nil {
+		return nil, false, errs.Wrap(err, "failed to subscribe to order changes")
+	}
+	return sub, false, nil
+}
+
+// eachSnapshot calls send with every order matching filter, a page at a
+// time.
+func (s *OrderServer) eachSnapshot(filter utils.KeyValue, send func(*entity.Order) error) error {
+	next := ""
+	for {
+		orders, token, err := s.Orders.Find(filter, db.Query{Limit: snapshotPage, PageToken: next})
+		if err != nil {
+			return errs.Wrap(err, "failed to read order snapshot")
+		}
+		for _, order := range orders {
+			if err := send(order); err != nil {
+				return err
+			}
+		}
+		if token == "" {
+			return nil
+		}
+		next = token
+	}
+}
+
+// forward hands the events of sub to send until the client goes away or
+// the broadcaster ends the subscription.
+func forward(ctx context.Context, sub *events.Subscription, send func(events.Event) error) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-sub.Events():
+			if !ok {
+				if errors.Is(sub.Err(), events.ErrSlowConsumer) {
+					return errs.NewResourceExhausted("order stream fell behind, reconnect")
+				}
+				return errs.NewUnavailable(sub.Err(), "order stream interrupted")
+			}
+			if err := send(event); err != nil {
+				return err
+			}
+		}
+	}
+}
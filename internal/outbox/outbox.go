@@ -0,0 +1,37 @@
+// Package outbox publishes the messages written to the outbox together
+// with the changes they report.
+//
+// A change and its message commit in one transaction, and a Relay
+// publishes the pending messages afterwards, so no committed change goes
+// unreported even when the process dies right after the commit. Delivery is
+// at least once: a message is published again when marking it published
+// fails or when several relays pick it up, and consumers drop duplicates by
+// the record id.
+package outbox
+
+import (
+	"awesomeProject/internal/entity"
+	"awesomeProject/internal/events"
+	"encoding/json"
+	"fmt"
+)
+
+// OrdersTopic is the topic of order change messages.
+const OrdersTopic = "orders"
+
+// OrderRecord builds the message of an order change. The payload is the
+// JSON form of an events.Event, the same the order event stream carries.
+func OrderRecord(eventType events.Type, order *entity.Order, previous entity.OrderStatus) (*entity.OutboxRecord, error) {
+	payload, err := json.Marshal(events.Event{
+		Type:           eventType,
+		OrderID:        order.ID,
+		Order:          order,
+		Status:         order.Status,
+		PreviousStatus: previous,
+		CustomerID:     order.CustomerId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("outbox: encode order %s: %w", order.ID.Hex(), err)
+	}
+	return entity.NewOutboxRecord(OrdersTopic, order.ID.Hex(), string(payload)), nil
+}
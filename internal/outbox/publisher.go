@@ -0,0 +1,60 @@
+package outbox
+
+import (
+	"awesomeProject/internal/entity"
+	"awesomeProject/pkg/cache"
+	"context"
+	"github.com/sirupsen/logrus"
+)
+
+// Publisher delivers outbox messages downstream. A message may be handed
+// to it more than once.
+type Publisher interface {
+	Publish(ctx context.Context, record *entity.OutboxRecord) error
+}
+
+// LogPublisher only logs the messages, for development.
+type LogPublisher struct {
+	Log *logrus.Logger
+}
+
+func (p LogPublisher) Publish(ctx context.Context, record *entity.OutboxRecord) error {
+	p.Log.WithFields(logrus.Fields{
+		"id":    record.ID.Hex(),
+		"topic": record.Topic,
+		"key":   record.Key,
+	}).Info("Published outbox message")
+	return nil
+}
+
+// StreamPublisher appends the messages to a Redis stream. Each entry holds
+// the record id, topic, key and payload fields. The stream is not trimmed,
+// that is up to its consumers.
+type StreamPublisher struct {
+	Streams cache.Streams
+	Key     string
+}
+
+func (p StreamPublisher) Publish(ctx context.Context, record *entity.OutboxRecord) error {
+	_, err := p.Streams.XAdd(p.Key, 0, map[string]string{
+		"id":      record.ID.Hex(),
+		"topic":   record.Topic,
+		"key":     record.Key,
+		"payload": record.Payload,
+	})
+	return err
+}
+
+// Publishers hands each message to several publishers in turn. When one
+// fails the message is retried on all of them, so each must cope with
+// duplicates.
+type Publishers []Publisher
+
+func (p Publishers) Publish(ctx context.Context, record *entity.OutboxRecord) error {
+	for _, publisher := range p {
+		if err := publisher.Publish(ctx, record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package outbox
+
+import (
+	"awesomeProject/internal/repository"
+	"context"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+// pruneInterval is how often published messages past their retention are
+// deleted.
+const pruneInterval = time.Minute
+
+type Options struct {
+	// Interval is the pause between polls of an empty or failing outbox.
+	Interval time.Duration
+	// Batch is the number of messages read per poll.
+	Batch int
+	// Retention is how long published messages are kept, 0 for ever.
+	Retention time.Duration
+}
+
+// Relay publishes the pending outbox messages in the order they were
+// written. A failed delivery is counted on the message and retried on the
+// next poll, holding back the messages after it.
+type Relay struct {
+	log       *logrus.Logger
+	records   repository.OutboxRepository
+	publisher Publisher
+	opts      Options
+	lastPrune time.Time
+}
+
+func NewRelay(log *logrus.Logger, records repository.OutboxRepository, publisher Publisher, opts Options) *Relay {
+	if opts.Interval <= 0 {
+		opts.Interval = time.Second
+	}
+	if opts.Batch <= 0 {
+		opts.Batch = 100
+	}
+	return &Relay{log: log, records: records, publisher: publisher, opts: opts}
+}
+
+// Run polls the outbox until ctx is done. Full batches are followed by the
+// next one right away.
+func (r *Relay) Run(ctx context.Context) {
+	for {
+		published, err := r.publishPending(ctx)
+		if err != nil && ctx.Err() == nil {
+			r.log.WithError(err).Warning("Failed to publish outbox messages")
+		}
+		r.prune()
+		if err == nil && published == r.opts.Batch {
+			continue
+		}
+		select {
+		case <-time.After(r.opts.Interval):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// publishPending publishes one batch, stopping at the first failure.
+func (r *Relay) publishPending(ctx context.Context) (int, error) {
+	records, err := r.records.Pending(r.opts.Batch)
+	if err != nil {
+		return 0, err
+	}
+	for i, record := range records {
+		if err := r.publisher.Publish(ctx, record); err != nil {
+			if markErr := r.records.MarkFailed(record.ID, err.Error()); markErr != nil {
+				r.log.WithError(markErr).Warningf("Failed to record the failed delivery of outbox message %s", record.ID.Hex())
+			}
+			return i, err
+		}
+		if err := r.records.MarkPublished(record.ID, time.Now()); err != nil {
+			// The message goes out again on the next poll.
+			return i, err
+		}
+	}
+	return len(records), nil
+}
+
+func (r *Relay) prune() {
+	if r.opts.Retention <= 0 || time.Since(r.lastPrune) < pruneInterval {
+		return
+	}
+	r.lastPrune = time.Now()
+	deleted, err := r.records.DeletePublished(time.Now().Add(-r.opts.Retention))
+	if err != nil {
+		r.log.WithError(err).Warning("Failed to delete published outbox messages")
+		return
+	}
+	if deleted > 0 {
+		r.log.Infof("Deleted %d published outbox messages", deleted)
+	}
+}
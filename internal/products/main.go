@@ -0,0 +1,231 @@
+package products
+
+import (
+	"awesomeProject/internal/entity"
+	"awesomeProject/internal/errs"
+	"awesomeProject/internal/mapper"
+	pb2 "awesomeProject/internal/orders/pb"
+	"awesomeProject/internal/repository"
+	"awesomeProject/internal/validation"
+	"awesomeProject/pkg/db"
+	"awesomeProject/pkg/money"
+	"awesomeProject/pkg/utils"
+	"context"
+	"errors"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 500
+)
+
+// ProductServer manages the product catalog orders are placed from.
+type ProductServer struct {
+	pb2.UnimplementedProductsServer
+	Log      *logrus.Logger
+	Products repository.ProductRepository
+}
+
+func (s *ProductServer) CreateProduct(ctx context.Context, req *pb2.CreateProductReq) (*pb2.CreateProductRes, error) {
+	reqProduct := req.GetProduct()
+	if reqProduct == nil {
+		return nil, errs.NewFieldViolation("product", "is required")
+	}
+
+	product := entity.NewProduct()
+	product.ID = primitive.NewObjectID()
+	product.Name = reqProduct.GetName()
+	product.Description = reqProduct.GetDescription()
+	product.Price = mapper.MoneyFromProto(reqProduct.GetPrice())
+	product.SKU = reqProduct.GetSku()
+	var violations validation.Violations
+	checkPrice(product.Price, &violations)
+	violations.Struct("product", &product)
+	if err := violations.Err(); err != nil {
+		return nil, err
+	}
+	if err := s.checkSKU(product.SKU, product.ID); err != nil {
+		return nil, err
+	}
+
+	if _, err := product.Persist(s.Products); err != nil {
+		if errors.Is(err, db.ErrDuplicateKey) {
+			return nil, errs.NewConflict("a product with sku %s already exists", product.SKU)
+		}
+		return nil, errs.Wrap(err, "failed to create product")
+	}
+	return &pb2.CreateProductRes{Product: mapper.ProductToProto(&product)}, nil
+}
+
+func (s *ProductServer) GetProduct(ctx context.Context, req *pb2.GetProductReq) (*pb2.GetProductRes, error) {
+	var violations validation.Violations
+	id := violations.ObjectID("id", req.GetId())
+	if err := violations.Err(); err != nil {
+		return nil, err
+	}
+
+	product, err := s.getProduct(id)
+	if err != nil {
+		return nil, err
+	}
+	return &pb2.GetProductRes{Product: mapper.ProductToProto(product)}, nil
+}
+
+// ListProducts returns one page of the catalog, oldest first. Clients pass
+// nextPageToken back as pageToken until it comes back empty.
+func (s *ProductServer) ListProducts(ctx context.Context, req *pb2.ListProductsReq) (*pb2.ListProductsRes, error) {
+	var violations validation.Violations
+	pageSize := int64(req.GetPageSize())
+	switch {
+	case pageSize < 0:
+		violations.Add("pageSize", "must not be negative")
+	case pageSize == 0:
+		pageSize = defaultPageSize
+	case pageSize > maxPageSize:
+		pageSize = maxPageSize
+	}
+	if err := violations.Err(); err != nil {
+		return nil, err
+	}
+
+	filter := utils.KeyValue{}
+	if !req.GetIncludeArchived() {
+		filter["archived"] = bson.M{"$ne": true}
+	}
+	query := db.Query{Sort: "createdAt", Limit: pageSize, PageToken: req.GetPageToken()}
+	products, next, err := s.Products.Find(filter, query)
+	if errors.Is(err, db.ErrInvalidPageToken) {
+		return nil, errs.NewFieldViolation("pageToken", "is invalid or belongs to a different query")
+	}
+	if err != nil {
+		return nil, errs.Wrap(err, "failed to list products")
+	}
+
+	res := &pb2.ListProductsRes{Products: make([]*pb2.Product, 0, len(products)), NextPageToken: next}
+	for _, product := range products {
+		res.Products = append(res.Products, mapper.ProductToProto(product))
+	}
+	return res, nil
+}
+
+// UpdateProduct replaces the name, description, price and SKU of a
+// product. Orders placed before keep the values they were placed with.
+func (s *ProductServer) UpdateProduct(ctx context.Context, req *pb2.UpdateProductReq) (*pb2.UpdateProductRes, error) {
+	reqProduct := req.GetProduct()
+	if reqProduct == nil {
+		return nil, errs.NewFieldViolation("product", "is required")
+	}
+
+	var violations validation.Violations
+	id := violations.ObjectID("product.id", reqProduct.GetId())
+	update := entity.NewProduct()
+	update.Name = reqProduct.GetName()
+	update.Description = reqProduct.GetDescription()
+	update.Price = mapper.MoneyFromProto(reqProduct.GetPrice())
+	update.SKU = reqProduct.GetSku()
+	checkPrice(update.Price, &violations)
+	violations.Struct("product", &update)
+	if err := violations.Err(); err != nil {
+		return nil, err
+	}
+
+	product, err := s.getProduct(id)
+	if err != nil {
+		return nil, err
+	}
+	if product.Archived {
+		return nil, errs.NewFailedPrecondition("product %s is archived", id.Hex())
+	}
+	if err := s.checkSKU(update.SKU, id); err != nil {
+		return nil, err
+	}
+	product.Name = update.Name
+	product.Description = update.Description
+	product.Price = update.Price
+	product.SKU = update.SKU
+	if product.CreatedAt.IsZero() {
+		// Products created before CreatedAt was set would otherwise be
+		// inserted again instead of replaced.
+		product.CreatedAt = product.ID.Timestamp()
+	}
+	if _, err := product.Persist(s.Products); err != nil {
+		if errors.Is(err, db.ErrDuplicateKey) {
+			return nil, errs.NewConflict("a product with sku %s already exists", product.SKU)
+		}
+		return nil, errs.Wrap(err, "failed to update product")
+	}
+	return &pb2.UpdateProductRes{Product: mapper.ProductToProto(product)}, nil
+}
+
+// ArchiveProduct takes a product out of the catalog. Archiving twice is a
+// no-op.
+func (s *ProductServer) ArchiveProduct(ctx context.Context, req *pb2.ArchiveProductReq) (*pb2.ArchiveProductRes, error) {
+	var violations validation.Violations
+	id := violations.ObjectID("id", req.GetId())
+	if err := violations.Err(); err != nil {
+		return nil, err
+	}
+
+	product, err := s.getProduct(id)
+	if err != nil {
+		return nil, err
+	}
+	if !product.Archived {
+		product.Archived = true
+		if product.CreatedAt.IsZero() {
+			product.CreatedAt = product.ID.Timestamp()
+		}
+		if _, err := product.Persist(s.Products); err != nil {
+			return nil, errs.Wrap(err, "failed to archive product")
+		}
+	}
+	return &pb2.ArchiveProductRes{Product: mapper.ProductToProto(product)}, nil
+}
+
+// checkPrice requires a positive catalog price; the money validate tags
+// alone allow free products.
+func checkPrice(price money.Money, violations *validation.Violations) {
+	if price.Amount <= 0 {
+		violations.Add("product.price.amount", "must be greater than 0")
+	}
+}
+
+// checkSKU rejects a SKU another product already uses. The unique index
+// catches races; this gives a clear error in the common case.
+func (s *ProductServer) checkSKU(sku string, id primitive.ObjectID) error {
+	existing := entity.NewProduct()
+	_, err := existing.GetProduct(s.Products, utils.KeyValue{"sku": sku})
+	switch {
+	case errors.Is(err, db.ErrNotFound):
+		return nil
+	case err != nil:
+		return errs.Wrap(err, "failed to check sku")
+	case existing.ID != id:
+		return errs.NewConflict("a product with sku %s already exists", sku)
+	}
+	return nil
+}
+
+// getProduct loads a product by id, reporting a missing one as not found.
+func (s *ProductServer) getProduct(id primitive.ObjectID) (*entity.Product, error) {
+	product := entity.NewProduct()
+	_, err := product.GetProduct(s.Products, utils.KeyValue{"_id": id})
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, errs.NewNotFound("product %s not found", id.Hex())
+	}
+	if err != nil {
+		return nil, errs.Wrap(err, "failed to get product")
+	}
+	return &product, nil
+}
+
+func NewProductServer(log *logrus.Logger, repos *repository.Repositories) *ProductServer {
+	return &ProductServer{
+		UnimplementedProductsServer: pb2.UnimplementedProductsServer{},
+		Log:                         log,
+		Products:                    repos.Products,
+	}
+}
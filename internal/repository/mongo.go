@@ -0,0 +1,359 @@
+package repository
+
+import (
+	"awesomeProject/internal/entity"
+	"awesomeProject/pkg/db"
+	"awesomeProject/pkg/utils"
+	"errors"
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+type MongoOrderRepository struct {
+	Store db.Store
+}
+
+func NewMongoOrderRepository(store db.Store) *MongoOrderRepository {
+	return &MongoOrderRepository{Store: store}
+}
+
+func (r *MongoOrderRepository) Insert(order *entity.Order) error {
+	return r.Store.Insert(entity.OrderCollectionName, order)
+}
+
+func (r *MongoOrderRepository) Replace(order *entity.Order) error {
+	filter := bson.M{"_id": bson.M{"$eq": order.ID}, "status": bson.M{"$eq": order.Status}}
+	update := bson.M{"$set": bson.M{
+		"orderNo":      order.OrderNo,
+		"items":        order.Items,
+		"customerId":   order.CustomerId,
+		"deliveryDate": order.DeliveryDate,
+		"createdAt":    order.CreatedAt,
+		"updatedAt":    order.UpdatedAt,
+		"subtotal":     order.Subtotal,
+		"tax":          order.Tax,
+		"shipping":     order.Shipping,
+		"total":        order.Total,
+	}}
+
+	result, err := r.Store.UpdateOne(entity.OrderCollectionName, filter, update, options.UpdateOptions{})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return r.replaceMissed(order.ID)
+	}
+	return nil
+}
+
+// replaceMissed tells why a Replace matched nothing: the order is gone, or
+// its status changed.
+func (r *MongoOrderRepository) replaceMissed(id primitive.ObjectID) error {
+	n, err := r.Count(utils.KeyValue{"_id": id})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return db.ErrNotFound
+	}
+	return entity.ErrStatusChanged
+}
+
+func (r *MongoOrderRepository) Get(filter utils.KeyValue, order *entity.Order) error {
+	return r.Store.Get(entity.OrderCollectionName, filter, order)
+}
+
+func (r *MongoOrderRepository) GetAll(filter utils.KeyValue) (entity.Orders, error) {
+	orders := entity.Orders{}
+	err := r.Store.GetAll(entity.OrderCollectionName, filter, &orders)
+	return orders, err
+}
+
+func (r *MongoOrderRepository) Find(filter utils.KeyValue, query db.Query) (entity.Orders, string, error) {
+	orders := entity.Orders{}
+	next, err := r.Store.Find(entity.OrderCollectionName, filter, query, &orders)
+	return orders, next, err
+}
+
+func (r *MongoOrderRepository) Count(filter utils.KeyValue) (int64, error) {
+	return r.Store.Count(entity.OrderCollectionName, filter)
+}
+
+func (r *MongoOrderRepository) UpdateStatus(id primitive.ObjectID, from entity.OrderStatus, to entity.OrderStatus, at time.Time) (int64, error) {
+	filter := bson.M{"_id": bson.M{"$eq": id}, "status": bson.M{"$eq": from}}
+	// previousStatus is written so that the change event of this update
+	// carries both ends of the transition.
+	update := bson.M{"$set": bson.M{"status": to, "previousStatus": from, "updatedAt": at}}
+
+	result, err := r.Store.UpdateOne(entity.OrderCollectionName, filter, update, options.UpdateOptions{})
+	if err != nil {
+		return -1, err
+	}
+	return result.MatchedCount, nil
+}
+
+func (r *MongoOrderRepository) Watch(waitTime time.Duration, resumeAfter bson.Raw) (db.ChangeStream, error) {
+	return r.Store.Watch(entity.OrderCollectionName, waitTime, resumeAfter)
+}
+
+type MongoCustomerRepository struct {
+	Store db.Store
+}
+
+func NewMongoCustomerRepository(store db.Store) *MongoCustomerRepository {
+	return &MongoCustomerRepository{Store: store}
+}
+
+func (r *MongoCustomerRepository) Insert(customer *entity.Customer) error {
+	return r.Store.Insert(entity.CustomerCollectionName, customer)
+}
+
+func (r *MongoCustomerRepository) Replace(customer *entity.Customer) error {
+	return r.Store.Replace(entity.CustomerCollectionName, utils.KeyValue{"_id": customer.ID}, customer)
+}
+
+func (r *MongoCustomerRepository) Get(filter utils.KeyValue, customer *entity.Customer) error {
+	return r.Store.Get(entity.CustomerCollectionName, filter, customer)
+}
+
+func (r *MongoCustomerRepository) GetAll(filter utils.KeyValue) ([]*entity.Customer, error) {
+	customers := make([]*entity.Customer, 0)
+	err := r.Store.GetAll(entity.CustomerCollectionName, filter, &customers)
+	return customers, err
+}
+
+func (r *MongoCustomerRepository) Find(filter utils.KeyValue, query db.Query) ([]*entity.Customer, string, error) {
+	customers := make([]*entity.Customer, 0)
+	next, err := r.Store.Find(entity.CustomerCollectionName, filter, query, &customers)
+	return customers, next, err
+}
+
+func (r *MongoCustomerRepository) Delete(id primitive.ObjectID) error {
+	return r.Store.Delete(entity.CustomerCollectionName, utils.KeyValue{"_id": id})
+}
+
+type MongoProductRepository struct {
+	Store db.Store
+}
+
+func NewMongoProductRepository(store db.Store) *MongoProductRepository {
+	return &MongoProductRepository{Store: store}
+}
+
+func (r *MongoProductRepository) Insert(product *entity.Product) error {
+	return r.Store.Insert(entity.ProductCollectionName, product)
+}
+
+func (r *MongoProductRepository) Replace(product *entity.Product) error {
+	return r.Store.Replace(entity.ProductCollectionName, utils.KeyValue{"_id": product.ID}, product)
+}
+
+func (r *MongoProductRepository) Get(filter utils.KeyValue, product *entity.Product) error {
+	return r.Store.Get(entity.ProductCollectionName, filter, product)
+}
+
+func (r *MongoProductRepository) GetAll(filter utils.KeyValue) ([]*entity.Product, error) {
+	products := make([]*entity.Product, 0)
+	err := r.Store.GetAll(entity.ProductCollectionName, filter, &products)
+	return products, err
+}
+
+func (r *MongoProductRepository) Find(filter utils.KeyValue, query db.Query) ([]*entity.Product, string, error) {
+	products := make([]*entity.Product, 0)
+	next, err := r.Store.Find(entity.ProductCollectionName, filter, query, &products)
+	return products, next, err
+}
+
+// SequenceCollectionName holds one counter document per sequence.
+const SequenceCollectionName = "counters"
+
+type MongoSequenceRepository struct {
+	Store db.Store
+}
+
+func NewMongoSequenceRepository(store db.Store) *MongoSequenceRepository {
+	return &MongoSequenceRepository{Store: store}
+}
+
+// Next increments the counter atomically, creating it on first use.
+func (r *MongoSequenceRepository) Next(name string) (int64, error) {
+	opt := options.FindOneAndUpdateOptions{}
+	opt.SetUpsert(true).SetReturnDocument(options.After)
+	update := bson.M{"$inc": bson.M{"seq": int64(1)}}
+
+	doc, err := r.Store.FindOneAndUpdate(SequenceCollectionName, utils.KeyValue{"_id": name}, update, opt)
+	if errors.Is(err, db.ErrDuplicateKey) {
+		// Two first uses raced to create the counter; it exists now.
+		doc, err = r.Store.FindOneAndUpdate(SequenceCollectionName, utils.KeyValue{"_id": name}, update, opt)
+	}
+	if err != nil {
+		return 0, err
+	}
+	switch seq := doc["seq"].(type) {
+	case int64:
+		return seq, nil
+	case int32:
+		return int64(seq), nil
+	}
+	return 0, fmt.Errorf("repository: counter %s holds %T", name, doc["seq"])
+}
+
+type MongoOutboxRepository struct {
+	Store db.Store
+}
+
+func NewMongoOutboxRepository(store db.Store) *MongoOutboxRepository {
+	return &MongoOutboxRepository{Store: store}
+}
+
+func (r *MongoOutboxRepository) Insert(record *entity.OutboxRecord) error {
+	return r.Store.Insert(entity.OutboxCollectionName, record)
+}
+
+func (r *MongoOutboxRepository) Pending(limit int) ([]*entity.OutboxRecord, error) {
+	var records []*entity.OutboxRecord
+	_, err := r.Store.Find(entity.OutboxCollectionName, utils.KeyValue{"publishedAt": time.Time{}}, db.Query{Limit: int64(limit)}, &records)
+	return records, err
+}
+
+func (r *MongoOutboxRepository) MarkPublished(id primitive.ObjectID, at time.Time) error {
+	update := bson.M{"$set": bson.M{"publishedAt": at}}
+	_, err := r.Store.UpdateOne(entity.OutboxCollectionName, bson.M{"_id": id}, update, options.UpdateOptions{})
+	return err
+}
+
+func (r *MongoOutboxRepository) MarkFailed(id primitive.ObjectID, reason string) error {
+	update := bson.M{"$inc": bson.M{"attempts": 1}, "$set": bson.M{"lastError": reason}}
+	_, err := r.Store.UpdateOne(entity.OutboxCollectionName, bson.M{"_id": id}, update, options.UpdateOptions{})
+	return err
+}
+
+// DeletePublished deletes a page of records at a time, as Store.Delete
+// removes a single document.
+func (r *MongoOutboxRepository) DeletePublished(before time.Time) (int64, error) {
+	filter := utils.KeyValue{"publishedAt": bson.M{"$gt": time.Time{}, "$lt": before}}
+	var deleted int64
+	for {
+		var records []*entity.OutboxRecord
+		if _, err := r.Store.Find(entity.OutboxCollectionName, filter, db.Query{Limit: 100}, &records); err != nil {
+			return deleted, err
+		}
+		if len(records) == 0 {
+			return deleted, nil
+		}
+		for _, record := range records {
+			if err := r.Store.Delete(entity.OutboxCollectionName, utils.KeyValue{"_id": record.ID}); err != nil {
+				return deleted, err
+			}
+			deleted++
+		}
+	}
+}
+
+type MongoWebhookRepository struct {
+	Store db.Store
+}
+
+func NewMongoWebhookRepository(store db.Store) *MongoWebhookRepository {
+	return &MongoWebhookRepository{Store: store}
+}
+
+func (r *MongoWebhookRepository) Insert(webhook *entity.Webhook) error {
+	return r.Store.Insert(entity.WebhookCollectionName, webhook)
+}
+
+func (r *MongoWebhookRepository) Replace(webhook *entity.Webhook) error {
+	return r.Store.Replace(entity.WebhookCollectionName, utils.KeyValue{"_id": webhook.ID}, webhook)
+}
+
+func (r *MongoWebhookRepository) Get(filter utils.KeyValue, webhook *entity.Webhook) error {
+	return r.Store.Get(entity.WebhookCollectionName, filter, webhook)
+}
+
+func (r *MongoWebhookRepository) GetAll(filter utils.KeyValue) ([]*entity.Webhook, error) {
+	webhooks := make([]*entity.Webhook, 0)
+	err := r.Store.GetAll(entity.WebhookCollectionName, filter, &webhooks)
+	return webhooks, err
+}
+
+func (r *MongoWebhookRepository) Find(filter utils.KeyValue, query db.Query) ([]*entity.Webhook, string, error) {
+	webhooks := make([]*entity.Webhook, 0)
+	next, err := r.Store.Find(entity.WebhookCollectionName, filter, query, &webhooks)
+	return webhooks, next, err
+}
+
+func (r *MongoWebhookRepository) Delete(id primitive.ObjectID) error {
+	return r.Store.Delete(entity.WebhookCollectionName, utils.KeyValue{"_id": id})
+}
+
+type MongoWebhookDeliveryRepository struct {
+	Store db.Store
+}
+
+func NewMongoWebhookDeliveryRepository(store db.Store) *MongoWebhookDeliveryRepository {
+	return &MongoWebhookDeliveryRepository{Store: store}
+}
+
+func (r *MongoWebhookDeliveryRepository) Insert(delivery *entity.WebhookDelivery) error {
+	return r.Store.Insert(entity.WebhookDeliveryCollectionName, delivery)
+}
+
+func (r *MongoWebhookDeliveryRepository) Replace(delivery *entity.WebhookDelivery) error {
+	return r.Store.Replace(entity.WebhookDeliveryCollectionName, utils.KeyValue{"_id": delivery.ID}, delivery)
+}
+
+func (r *MongoWebhookDeliveryRepository) Get(filter utils.KeyValue, delivery *entity.WebhookDelivery) error {
+	return r.Store.Get(entity.WebhookDeliveryCollectionName, filter, delivery)
+}
+
+func (r *MongoWebhookDeliveryRepository) Find(filter utils.KeyValue, query db.Query) ([]*entity.WebhookDelivery, string, error) {
+	deliveries := make([]*entity.WebhookDelivery, 0)
+	next, err := r.Store.Find(entity.WebhookDeliveryCollectionName, filter, query, &deliveries)
+	return deliveries, next, err
+}
+
+func (r *MongoWebhookDeliveryRepository) Claim(id primitive.ObjectID, due time.Time, until time.Time) (bool, error) {
+	filter := bson.M{"_id": id, "status": entity.DeliveryPending, "nextAttemptAt": due}
+	update := bson.M{"$set": bson.M{"nextAttemptAt": until}}
+	result, err := r.Store.UpdateOne(entity.WebhookDeliveryCollectionName, filter, update, options.UpdateOptions{})
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// NewMongoRepositories builds every repository on top of one document
+// store, either a MongoStore or a MemoryStore.
+func NewMongoRepositories(store db.Store) *Repositories {
+	return &Repositories{
+		Orders:     NewMongoOrderRepository(store),
+		Customers:  NewMongoCustomerRepository(store),
+		Products:   NewMongoProductRepository(store),
+		Sequences:  NewMongoSequenceRepository(store),
+		Outbox:     NewMongoOutboxRepository(store),
+		Webhooks:   NewMongoWebhookRepository(store),
+		Deliveries: NewMongoWebhookDeliveryRepository(store),
+		transaction: func(fn func(tx *Repositories) error) error {
+			return store.Transaction(func(tx db.Store) error {
+				return fn(NewMongoRepositories(tx))
+			})
+		},
+	}
+}
+
+// EnsureMongoIndexes creates the unique indexes the repositories rely on.
+func EnsureMongoIndexes(store db.Store) error {
+	if err := store.EnsureUniqueIndex(entity.OrderCollectionName, "orderNo"); err != nil {
+		return fmt.Errorf("repository: unique orderNo index: %w", err)
+	}
+	if err := store.EnsureUniqueIndex(entity.ProductCollectionName, "sku"); err != nil {
+		return fmt.Errorf("repository: unique sku index: %w", err)
+	}
+	if err := store.EnsureUniqueIndex(entity.WebhookDeliveryCollectionName, "dedupKey"); err != nil {
+		return fmt.Errorf("repository: unique dedupKey index: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"awesomeProject/internal/entity"
+	"awesomeProject/pkg/db"
+	"awesomeProject/pkg/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+// OrderRepository stores orders independently of the database behind it.
+type OrderRepository interface {
+	entity.OrderStore
+	// Find returns one page of orders and the token of the next page.
+	Find(filter utils.KeyValue, query db.Query) (entity.Orders, string, error)
+	Count(filter utils.KeyValue) (int64, error)
+	// Watch streams order changes, continuing after the change event with
+	// the _id resumeAfter when it is not nil.
+	Watch(waitTime time.Duration, resumeAfter bson.Raw) (db.ChangeStream, error)
+}
+
+// CustomerRepository stores customers independently of the database behind it.
+type CustomerRepository interface {
+	entity.CustomerStore
+	GetAll(filter utils.KeyValue) ([]*entity.Customer, error)
+	// Find returns one page of customers and the token of the next page.
+	Find(filter utils.KeyValue, query db.Query) ([]*entity.Customer, string, error)
+	Delete(id primitive.ObjectID) error
+}
+
+// ProductRepository stores products independently of the database behind it.
+type ProductRepository interface {
+	entity.ProductStore
+	GetAll(filter utils.KeyValue) ([]*entity.Product, error)
+	// Find returns one page of products and the token of the next page.
+	Find(filter utils.KeyValue, query db.Query) ([]*entity.Product, string, error)
+}
+
+// SequenceRepository hands out increasing numbers, starting at 1, for each
+// named sequence. Numbers are never handed out twice, but may be skipped.
+type SequenceRepository interface {
+	Next(name string) (int64, error)
+}
+
+// OutboxRepository stores the messages the outbox relay publishes.
+type OutboxRepository interface {
+	Insert(record *entity.OutboxRecord) error
+	// Pending returns at most limit unpublished records, oldest first.
+	Pending(limit int) ([]*entity.OutboxRecord, error)
+	MarkPublished(id primitive.ObjectID, at time.Time) error
+	// MarkFailed counts a failed delivery attempt.
+	MarkFailed(id primitive.ObjectID, reason string) error
+	// DeletePublished removes records published before a time and
+	// returns how many it removed.
+	DeletePublished(before time.Time) (int64, error)
+}
+
+// WebhookRepository stores webhook subscriptions independently of the
+// database behind it.
+type WebhookRepository interface {
+	entity.WebhookStore
+	GetAll(filter utils.KeyValue) ([]*entity.Webhook, error)
+	// Find returns one page of webhooks and the token of the next page.
+	Find(filter utils.KeyValue, query db.Query) ([]*entity.Webhook, string, error)
+	Delete(id primitive.ObjectID) error
+}
+
+// WebhookDeliveryRepository stores the deliveries of events to webhooks.
+type WebhookDeliveryRepository interface {
+	// Insert fails with db.ErrDuplicateKey when the delivery's DedupKey is
+	// taken.
+	Insert(delivery *entity.WebhookDelivery) error
+	Replace(delivery *entity.WebhookDelivery) error
+	Get(filter utils.KeyValue, delivery *entity.WebhookDelivery) error
+	// Find returns one page of deliveries and the token of the next page.
+	Find(filter utils.KeyValue, query db.Query) ([]*entity.WebhookDelivery, string, error)
+	// Claim moves the next attempt of a pending delivery that is due at due
+	// to until, reporting whether it was still due then. Of several
+	// workers claiming a delivery only one succeeds.
+	Claim(id primitive.ObjectID, due time.Time, until time.Time) (bool, error)
+}
+
+// Repositories groups the repositories of one storage backend.
+type Repositories struct {
+	Orders    OrderRepository
+	Customers CustomerRepository
+	Products  ProductRepository
+	Sequences SequenceRepository
+	Outbox    OutboxRepository
+	Webhooks  WebhookRepository
+	// Deliveries are the webhook deliveries.
+	Deliveries WebhookDeliveryRepository
+
+	transaction func(fn func(tx *Repositories) error) error
+}
+
+// Transaction runs fn with repositories whose writes are committed together
+// when fn returns nil and discarded otherwise.
+func (r *Repositories) Transaction(fn func(tx *Repositories) error) error {
+	return r.transaction(fn)
+}
@@ -0,0 +1,863 @@
+package repository
+
+import (
+	"awesomeProject/internal/entity"
+	"awesomeProject/pkg/db"
+	"awesomeProject/pkg/utils"
+	"database/sql"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"strings"
+	"time"
+)
+
+// The SQL repositories store the same entities as the Mongo ones in a
+// relational schema (see MigrateSQL). They are written against the subset
+// of SQL shared by Postgres and SQLite, with $n placeholders, so either
+// driver can be used. Filters use the document field names the Mongo
+// repositories understand and are translated to columns.
+
+var orderColumns = map[string]string{
+	"_id":          "id",
+	"status":       "status",
+	"orderNo":      "order_no",
+	"customerId":   "customer_id",
+	"deliveryDate": "delivery_date",
+	"createdAt":    "created_at",
+	"updatedAt":    "updated_at",
+}
+
+var customerColumns = map[string]string{
+	"_id":       "id",
+	"name":      "name",
+	"createdAt": "created_at",
+	"updatedAt": "updated_at",
+}
+
+var productColumns = map[string]string{
+	"_id":       "id",
+	"name":      "name",
+	"sku":       "sku",
+	"archived":  "archived",
+	"createdAt": "created_at",
+	"updatedAt": "updated_at",
+}
+
+var webhookColumns = map[string]string{
+	"_id":       "id",
+	"url":       "url",
+	"disabled":  "disabled",
+	"createdAt": "created_at",
+	"updatedAt": "updated_at",
+}
+
+var deliveryColumns = map[string]string{
+	"_id":           "id",
+	"webhookId":     "webhook_id",
+	"eventId":       "event_id",
+	"status":        "status",
+	"nextAttemptAt": "next_attempt_at",
+	"createdAt":     "created_at",
+	"updatedAt":     "updated_at",
+}
+
+type SQLOrderRepository struct {
+	DB   SQLConn
+	Feed *db.ChangeFeed
+}
+
+func NewSQLOrderRepository(conn SQLConn, feed *db.ChangeFeed) *SQLOrderRepository {
+	return &SQLOrderRepository{DB: conn, Feed: feed}
+}
+
+func (r *SQLOrderRepository) Insert(order *entity.Order) error {
+	if order.ID.IsZero() {
+		order.ID = primitive.NewObjectID()
+	}
+	err := inTx(r.DB, func(tx SQLConn) error {
+		_, err := tx.Exec(
+			`INSERT INTO orders (id, status, order_no, customer_id, delivery_date, created_at, updated_at,
+				currency, subtotal, tax, shipping, total)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`,
+			order.ID.Hex(), order.Status, order.OrderNo, order.CustomerId.Hex(),
+			sqlTime(order.DeliveryDate), sqlTime(order.CreatedAt), sqlTime(order.UpdatedAt), order.Total.Currency,
+			order.Subtotal.Amount, order.Tax.Amount, order.Shipping.Amount, order.Total.Amount,
+		)
+		if err != nil {
+			return sqlError(err)
+		}
+		return insertOrderItems(tx, order)
+	})
+	if err != nil {
+		return err
+	}
+	return r.Feed.Publish(entity.OrderCollectionName, "insert", order.ID, order)
+}
+
+func (r *SQLOrderRepository) Replace(order *entity.Order) error {
+	err := inTx(r.DB, func(tx SQLConn) error {
+		result, err := tx.Exec(
+			`UPDATE orders SET order_no = $3, customer_id = $4, delivery_date = $5, created_at = $6,
+				updated_at = $7, currency = $8, subtotal = $9, tax = $10, shipping = $11, total = $12
+			WHERE id = $1 AND status = $2`,
+			order.ID.Hex(), order.Status, order.OrderNo, order.CustomerId.Hex(),
+			sqlTime(order.DeliveryDate), sqlTime(order.CreatedAt), sqlTime(order.UpdatedAt), order.Total.Currency,
+			order.Subtotal.Amount, order.Tax.Amount, order.Shipping.Amount, order.Total.Amount,
+		)
+		if err != nil {
+			return sqlError(err)
+		}
+		if n, _ := result.RowsAffected(); n == 0 {
+			// Either the order is gone or its status changed.
+			exists, err := sqlCount(tx, "orders", utils.KeyValue{"_id": order.ID}, orderColumns)
+			if err != nil {
+				return sqlError(err)
+			}
+			if exists == 0 {
+				return db.ErrNotFound
+			}
+			return entity.ErrStatusChanged
+		}
+		if _, err := tx.Exec(`DELETE FROM order_items WHERE order_id = $1`, order.ID.Hex()); err != nil {
+			return err
+		}
+		return insertOrderItems(tx, order)
+	})
+	if err != nil {
+		return err
+	}
+	return r.Feed.Publish(entity.OrderCollectionName, "replace", order.ID, order)
+}
+
+func (r *SQLOrderRepository) Get(filter utils.KeyValue, order *entity.Order) error {
+	orders, err := r.find(filter, db.Query{Limit: 1})
+	if err != nil {
+		return err
+	}
+	if len(orders) == 0 {
+		return db.ErrNotFound
+	}
+	*order = *orders[0]
+	return nil
+}
+
+func (r *SQLOrderRepository) GetAll(filter utils.KeyValue) (entity.Orders, error) {
+	return r.find(filter, db.Query{Sort: "createdAt"})
+}
+
+func (r *SQLOrderRepository) Find(filter utils.KeyValue, query db.Query) (entity.Orders, string, error) {
+	orders, err := r.find(filter, query)
+	if err != nil {
+		return nil, "", err
+	}
+	return sqlPage(orders, query, func(order *entity.Order) (interface{}, string) {
+		return orderSortValue(order, query.Sort), order.ID.Hex()
+	})
+}
+
+func (r *SQLOrderRepository) Count(filter utils.KeyValue) (int64, error) {
+	return sqlCount(r.DB, "orders", filter, orderColumns)
+}
+
+func (r *SQLOrderRepository) UpdateStatus(id primitive.ObjectID, from entity.OrderStatus, to entity.OrderStatus, at time.Time) (int64, error) {
+	result, err := r.DB.Exec(`UPDATE orders SET status = $3, updated_at = $4 WHERE id = $1 AND status = $2`,
+		id.Hex(), from, to, sqlTime(at))
+	if err != nil {
+		return -1, err
+	}
+	matched, err := result.RowsAffected()
+	if err != nil || matched == 0 {
+		return matched, err
+	}
+
+	var order entity.Order
+	if err := r.Get(utils.KeyValue{"_id": id}, &order); err != nil {
+		return -1, err
+	}
+	updated := bson.M{"status": to, "previousStatus": from, "updatedAt": at}
+	return matched, r.Feed.PublishUpdate(entity.OrderCollectionName, id, updated, order)
+}
+
+// Watch streams the changes made through this repository. SQL has no
+// change streams, so only writes from this process are seen, and nothing
+// can be resumed: resumeAfter is ignored.
+func (r *SQLOrderRepository) Watch(waitTime time.Duration, resumeAfter bson.Raw) (db.ChangeStream, error) {
+	return r.Feed.Watch(entity.OrderCollectionName), nil
+}
+
+func (r *SQLOrderRepository) find(filter utils.KeyValue, query db.Query) (entity.Orders, error) {
+	clauses, args, err := sqlSelect(filter, query, orderColumns)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.DB.Query(`SELECT id, status, order_no, customer_id, delivery_date, created_at, updated_at,
+		currency, subtotal, tax, shipping, total FROM orders`+clauses, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	orders := entity.Orders{}
+	byID := map[string]*entity.Order{}
+	for rows.Next() {
+		var (
+			order                              entity.Order
+			id, customerId                     string
+			deliveryDate, createdAt, updatedAt int64
+			currency                           string
+		)
+		err := rows.Scan(&id, &order.Status, &order.OrderNo, &customerId, &deliveryDate, &createdAt, &updatedAt,
+			&currency, &order.Subtotal.Amount, &order.Tax.Amount, &order.Shipping.Amount, &order.Total.Amount)
+		if err != nil {
+			return nil, err
+		}
+		order.Subtotal.Currency = currency
+		order.Tax.Currency = currency
+		order.Shipping.Currency = currency
+		order.Total.Currency = currency
+		order.ID, _ = primitive.ObjectIDFromHex(id)
+		order.CustomerId, _ = primitive.ObjectIDFromHex(customerId)
+		order.DeliveryDate = timeFromSQL(deliveryDate)
+		order.CreatedAt = timeFromSQL(createdAt)
+		order.UpdatedAt = timeFromSQL(updatedAt)
+		order.Items = []entity.OrderLine{}
+		orders = append(orders, &order)
+		byID[id] = &order
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(orders) == 0 {
+		return orders, nil
+	}
+	return orders, r.loadItems(byID)
+}
+
+func (r *SQLOrderRepository) loadItems(byID map[string]*entity.Order) error {
+	ids := make([]interface{}, 0, len(byID))
+	for id := range byID {
+		ids = append(ids, id)
+	}
+	rows, err := r.DB.Query(
+		`SELECT order_id, product_id, name, description, sku, quantity, unit_price, line_total, created_at FROM order_items
+		WHERE order_id IN (`+sqlPlaceholders(1, len(ids))+`) ORDER BY order_id, position`,
+		ids...,
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			item               entity.OrderLine
+			orderId, productId string
+			createdAt          int64
+		)
+		err := rows.Scan(&orderId, &productId, &item.Name, &item.Description, &item.SKU,
+			&item.Quantity, &item.UnitPrice.Amount, &item.LineTotal.Amount, &createdAt)
+		if err != nil {
+			return err
+		}
+		order := byID[orderId]
+		item.ProductID, _ = primitive.ObjectIDFromHex(productId)
+		item.UnitPrice.Currency = order.Total.Currency
+		item.LineTotal.Currency = order.Total.Currency
+		item.CreatedAt = timeFromSQL(createdAt)
+		order.Items = append(order.Items, item)
+	}
+	return rows.Err()
+}
+
+// orderSortValue is the column value an order is sorted by.
+func orderSortValue(order *entity.Order, field string) interface{} {
+	switch field {
+	case "status":
+		return string(order.Status)
+	case "orderNo":
+		return order.OrderNo
+	case "customerId":
+		return order.CustomerId.Hex()
+	case "deliveryDate":
+		return sqlTime(order.DeliveryDate)
+	case "createdAt":
+		return sqlTime(order.CreatedAt)
+	case "updatedAt":
+		return sqlTime(order.UpdatedAt)
+	}
+	return order.ID.Hex()
+}
+
+func insertOrderItems(tx SQLConn, order *entity.Order) error {
+	for i, item := range order.Items {
+		_, err := tx.Exec(
+			`INSERT INTO order_items (order_id, position, product_id, name, description, sku,
+				quantity, unit_price, line_total, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+			order.ID.Hex(), i, item.ProductID.Hex(), item.Name, item.Description, item.SKU,
+			item.Quantity, item.UnitPrice.Amount, item.LineTotal.Amount, sqlTime(item.CreatedAt),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type SQLCustomerRepository struct {
+	DB SQLConn
+}
+
+func NewSQLCustomerRepository(conn SQLConn) *SQLCustomerRepository {
+	return &SQLCustomerRepository{DB: conn}
+}
+
+func (r *SQLCustomerRepository) Insert(customer *entity.Customer) error {
+	if customer.ID.IsZero() {
+		customer.ID = primitive.NewObjectID()
+	}
+	_, err := r.DB.Exec(
+		`INSERT INTO customers (id, name, lat, lon, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6)`,
+		customer.ID.Hex(), customer.Name, customer.Position.Lat, customer.Position.Long,
+		sqlTime(customer.CreatedAt), sqlTime(customer.UpdatedAt),
+	)
+	return sqlError(err)
+}
+
+func (r *SQLCustomerRepository) Replace(customer *entity.Customer) error {
+	_, err := r.DB.Exec(
+		`UPDATE customers SET name = $2, lat = $3, lon = $4, created_at = $5, updated_at = $6 WHERE id = $1`,
+		customer.ID.Hex(), customer.Name, customer.Position.Lat, customer.Position.Long,
+		sqlTime(customer.CreatedAt), sqlTime(customer.UpdatedAt),
+	)
+	return sqlError(err)
+}
+
+func (r *SQLCustomerRepository) Get(filter utils.KeyValue, customer *entity.Customer) error {
+	customers, err := r.find(filter, db.Query{Limit: 1})
+	if err != nil {
+		return err
+	}
+	if len(customers) == 0 {
+		return db.ErrNotFound
+	}
+	*customer = *customers[0]
+	return nil
+}
+
+func (r *SQLCustomerRepository) GetAll(filter utils.KeyValue) ([]*entity.Customer, error) {
+	return r.find(filter, db.Query{Sort: "createdAt"})
+}
+
+func (r *SQLCustomerRepository) Find(filter utils.KeyValue, query db.Query) ([]*entity.Customer, string, error) {
+	customers, err := r.find(filter, query)
+	if err != nil {
+		return nil, "", err
+	}
+	return sqlPage(customers, query, func(customer *entity.Customer) (interface{}, string) {
+		switch query.Sort {
+		case "name":
+			return customer.Name, customer.ID.Hex()
+		case "createdAt":
+			return sqlTime(customer.CreatedAt), customer.ID.Hex()
+		}
+		return customer.ID.Hex(), customer.ID.Hex()
+	})
+}
+
+func (r *SQLCustomerRepository) Delete(id primitive.ObjectID) error {
+	_, err := r.DB.Exec(`DELETE FROM customers WHERE id = $1`, id.Hex())
+	return sqlError(err)
+}
+
+func (r *SQLCustomerRepository) find(filter utils.KeyValue, query db.Query) ([]*entity.Customer, error) {
+	clauses, args, err := sqlSelect(filter, query, customerColumns)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.DB.Query(`SELECT id, name, lat, lon, created_at, updated_at FROM customers`+clauses, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	customers := make([]*entity.Customer, 0)
+	for rows.Next() {
+		var (
+			customer             entity.Customer
+			id                   string
+			createdAt, updatedAt int64
+		)
+		err := rows.Scan(&id, &customer.Name, &customer.Position.Lat, &customer.Position.Long, &createdAt, &updatedAt)
+		if err != nil {
+			return nil, err
+		}
+		customer.ID, _ = primitive.ObjectIDFromHex(id)
+		customer.CreatedAt = timeFromSQL(createdAt)
+		customer.UpdatedAt = timeFromSQL(updatedAt)
+		customers = append(customers, &customer)
+	}
+	return customers, rows.Err()
+}
+
+type SQLProductRepository struct {
+	DB SQLConn
+}
+
+func NewSQLProductRepository(conn SQLConn) *SQLProductRepository {
+	return &SQLProductRepository{DB: conn}
+}
+
+func (r *SQLProductRepository) Insert(product *entity.Product) error {
+	if product.ID.IsZero() {
+		product.ID = primitive.NewObjectID()
+	}
+	_, err := r.DB.Exec(
+		`INSERT INTO products (id, name, description, price_amount, currency, sku, archived, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		product.ID.Hex(), product.Name, product.Description, product.Price.Amount, product.Price.Currency,
+		product.SKU, product.Archived, sqlTime(product.CreatedAt), sqlTime(product.UpdatedAt),
+	)
+	return sqlError(err)
+}
+
+func (r *SQLProductRepository) Replace(product *entity.Product) error {
+	_, err := r.DB.Exec(
+		`UPDATE products SET name = $2, description = $3, price_amount = $4, currency = $5, sku = $6, archived = $7,
+		created_at = $8, updated_at = $9 WHERE id = $1`,
+		product.ID.Hex(), product.Name, product.Description, product.Price.Amount, product.Price.Currency,
+		product.SKU, product.Archived, sqlTime(product.CreatedAt), sqlTime(product.UpdatedAt),
+	)
+	return sqlError(err)
+}
+
+func (r *SQLProductRepository) Get(filter utils.KeyValue, product *entity.Product) error {
+	products, err := r.find(filter, db.Query{Limit: 1})
+	if err != nil {
+		return err
+	}
+	if len(products) == 0 {
+		return db.ErrNotFound
+	}
+	*product = *products[0]
+	return nil
+}
+
+func (r *SQLProductRepository) GetAll(filter utils.KeyValue) ([]*entity.Product, error) {
+	return r.find(filter, db.Query{Sort: "createdAt"})
+}
+
+func (r *SQLProductRepository) Find(filter utils.KeyValue, query db.Query) ([]*entity.Product, string, error) {
+	products, err := r.find(filter, query)
+	if err != nil {
+		return nil, "", err
+	}
+	return sqlPage(products, query, func(product *entity.Product) (interface{}, string) {
+		switch query.Sort {
+		case "name":
+			return product.Name, product.ID.Hex()
+		case "sku":
+			return product.SKU, product.ID.Hex()
+		case "createdAt":
+			return sqlTime(product.CreatedAt), product.ID.Hex()
+		}
+		return product.ID.Hex(), product.ID.Hex()
+	})
+}
+
+func (r *SQLProductRepository) find(filter utils.KeyValue, query db.Query) ([]*entity.Product, error) {
+	clauses, args, err := sqlSelect(filter, query, productColumns)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.DB.Query(`SELECT id, name, description, price_amount, currency, sku, archived, created_at,
+		updated_at FROM products`+clauses, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	products := make([]*entity.Product, 0)
+	for rows.Next() {
+		var (
+			product              entity.Product
+			id                   string
+			createdAt, updatedAt int64
+		)
+		err := rows.Scan(&id, &product.Name, &product.Description, &product.Price.Amount, &product.Price.Currency,
+			&product.SKU, &product.Archived, &createdAt, &updatedAt)
+		if err != nil {
+			return nil, err
+		}
+		product.ID, _ = primitive.ObjectIDFromHex(id)
+		product.CreatedAt = timeFromSQL(createdAt)
+		product.UpdatedAt = timeFromSQL(updatedAt)
+		products = append(products, &product)
+	}
+	return products, rows.Err()
+}
+
+type SQLSequenceRepository struct {
+	DB SQLConn
+}
+
+func NewSQLSequenceRepository(conn SQLConn) *SQLSequenceRepository {
+	return &SQLSequenceRepository{DB: conn}
+}
+
+// Next increments the sequence in one upsert, creating it on first use.
+func (r *SQLSequenceRepository) Next(name string) (int64, error) {
+	var value int64
+	err := r.DB.QueryRow(
+		`INSERT INTO sequences (name, value) VALUES ($1, 1)
+		ON CONFLICT (name) DO UPDATE SET value = sequences.value + 1
+		RETURNING value`,
+		name,
+	).Scan(&value)
+	return value, err
+}
+
+type SQLOutboxRepository struct {
+	DB SQLConn
+}
+
+func NewSQLOutboxRepository(conn SQLConn) *SQLOutboxRepository {
+	return &SQLOutboxRepository{DB: conn}
+}
+
+func (r *SQLOutboxRepository) Insert(record *entity.OutboxRecord) error {
+	_, err := r.DB.Exec(
+		`INSERT INTO outbox (id, topic, record_key, payload, created_at, published_at, attempts, last_error)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		record.ID.Hex(), record.Topic, record.Key, record.Payload, sqlTime(record.CreatedAt),
+		sqlTime(record.PublishedAt), record.Attempts, record.LastError)
+	return err
+}
+
+func (r *SQLOutboxRepository) Pending(limit int) ([]*entity.OutboxRecord, error) {
+	rows, err := r.DB.Query(`SELECT id, topic, record_key, payload, created_at, attempts, last_error
+		FROM outbox WHERE published_at = 0 ORDER BY id LIMIT $1`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	records := make([]*entity.OutboxRecord, 0)
+	for rows.Next() {
+		var (
+			record    entity.OutboxRecord
+			id        string
+			createdAt int64
+		)
+		if err := rows.Scan(&id, &record.Topic, &record.Key, &record.Payload, &createdAt, &record.Attempts, &record.LastError); err != nil {
+			return nil, err
+		}
+		if record.ID, err = primitive.ObjectIDFromHex(id); err != nil {
+			return nil, err
+		}
+		record.CreatedAt = timeFromSQL(createdAt)
+		records = append(records, &record)
+	}
+	return records, rows.Err()
+}
+
+func (r *SQLOutboxRepository) MarkPublished(id primitive.ObjectID, at time.Time) error {
+	_, err := r.DB.Exec(`UPDATE outbox SET published_at = $2 WHERE id = $1`, id.Hex(), sqlTime(at))
+	return err
+}
+
+func (r *SQLOutboxRepository) MarkFailed(id primitive.ObjectID, reason string) error {
+	_, err := r.DB.Exec(`UPDATE outbox SET attempts = attempts + 1, last_error = $2 WHERE id = $1`, id.Hex(), reason)
+	return err
+}
+
+func (r *SQLOutboxRepository) DeletePublished(before time.Time) (int64, error) {
+	result, err := r.DB.Exec(`DELETE FROM outbox WHERE published_at > 0 AND published_at < $1`, sqlTime(before))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+type SQLWebhookRepository struct {
+	DB SQLConn
+}
+
+func NewSQLWebhookRepository(conn SQLConn) *SQLWebhookRepository {
+	return &SQLWebhookRepository{DB: conn}
+}
+
+func (r *SQLWebhookRepository) Insert(webhook *entity.Webhook) error {
+	if webhook.ID.IsZero() {
+		webhook.ID = primitive.NewObjectID()
+	}
+	_, err := r.DB.Exec(
+		`INSERT INTO webhooks (id, url, event_types, secret, disabled, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		webhook.ID.Hex(), webhook.URL, strings.Join(webhook.EventTypes, ","), webhook.Secret, webhook.Disabled,
+		sqlTime(webhook.CreatedAt), sqlTime(webhook.UpdatedAt),
+	)
+	return sqlError(err)
+}
+
+func (r *SQLWebhookRepository) Replace(webhook *entity.Webhook) error {
+	_, err := r.DB.Exec(
+		`UPDATE webhooks SET url = $2, event_types = $3, secret = $4, disabled = $5, created_at = $6, updated_at = $7
+		WHERE id = $1`,
+		webhook.ID.Hex(), webhook.URL, strings.Join(webhook.EventTypes, ","), webhook.Secret, webhook.Disabled,
+		sqlTime(webhook.CreatedAt), sqlTime(webhook.UpdatedAt),
+	)
+	return sqlError(err)
+}
+
+func (r *SQLWebhookRepository) Get(filter utils.KeyValue, webhook *entity.Webhook) error {
+	webhooks, err := r.find(filter, db.Query{Limit: 1})
+	if err != nil {
+		return err
+	}
+	if len(webhooks) == 0 {
+		return db.ErrNotFound
+	}
+	*webhook = *webhooks[0]
+	return nil
+}
+
+func (r *SQLWebhookRepository) GetAll(filter utils.KeyValue) ([]*entity.Webhook, error) {
+	return r.find(filter, db.Query{Sort: "createdAt"})
+}
+
+func (r *SQLWebhookRepository) Find(filter utils.KeyValue, query db.Query) ([]*entity.Webhook, string, error) {
+	webhooks, err := r.find(filter, query)
+	if err != nil {
+		return nil, "", err
+	}
+	return sqlPage(webhooks, query, func(webhook *entity.Webhook) (interface{}, string) {
+		if query.Sort == "createdAt" {
+			return sqlTime(webhook.CreatedAt), webhook.ID.Hex()
+		}
+		return webhook.ID.Hex(), webhook.ID.Hex()
+	})
+}
+
+func (r *SQLWebhookRepository) Delete(id primitive.ObjectID) error {
+	_, err := r.DB.Exec(`DELETE FROM webhooks WHERE id = $1`, id.Hex())
+	return err
+}
+
+func (r *SQLWebhookRepository) find(filter utils.KeyValue, query db.Query) ([]*entity.Webhook, error) {
+	clauses, args, err := sqlSelect(filter, query, webhookColumns)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.DB.Query(`SELECT id, url, event_types, secret, disabled, created_at, updated_at
+		FROM webhooks`+clauses, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	webhooks := make([]*entity.Webhook, 0)
+	for rows.Next() {
+		var (
+			webhook              entity.Webhook
+			id, eventTypes       string
+			createdAt, updatedAt int64
+		)
+		err := rows.Scan(&id, &webhook.URL, &eventTypes, &webhook.Secret, &webhook.Disabled, &createdAt, &updatedAt)
+		if err != nil {
+			return nil, err
+		}
+		webhook.ID, _ = primitive.ObjectIDFromHex(id)
+		webhook.EventTypes = []string{}
+		if eventTypes != "" {
+			webhook.EventTypes = strings.Split(eventTypes, ",")
+		}
+		webhook.CreatedAt = timeFromSQL(createdAt)
+		webhook.UpdatedAt = timeFromSQL(updatedAt)
+		webhooks = append(webhooks, &webhook)
+	}
+	return webhooks, rows.Err()
+}
+
+type SQLWebhookDeliveryRepository struct {
+	DB SQLConn
+}
+
+func NewSQLWebhookDeliveryRepository(conn SQLConn) *SQLWebhookDeliveryRepository {
+	return &SQLWebhookDeliveryRepository{DB: conn}
+}
+
+func (r *SQLWebhookDeliveryRepository) Insert(delivery *entity.WebhookDelivery) error {
+	if delivery.ID.IsZero() {
+		delivery.ID = primitive.NewObjectID()
+	}
+	_, err := r.DB.Exec(
+		`INSERT INTO webhook_deliveries (id, webhook_id, event_id, dedup_key, event_type, payload, status, attempts,
+			next_attempt_at, last_error, response_code, created_at, updated_at, delivered_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`,
+		delivery.ID.Hex(), delivery.WebhookID.Hex(), delivery.EventID, delivery.DedupKey, delivery.EventType,
+		delivery.Payload, delivery.Status, delivery.Attempts, sqlTime(delivery.NextAttemptAt), delivery.LastError,
+		delivery.ResponseCode, sqlTime(delivery.CreatedAt), sqlTime(delivery.UpdatedAt), sqlTime(delivery.DeliveredAt),
+	)
+	return sqlError(err)
+}
+
+// Replace writes everything but the webhook, event and payload, which
+// never change.
+func (r *SQLWebhookDeliveryRepository) Replace(delivery *entity.WebhookDelivery) error {
+	_, err := r.DB.Exec(
+		`UPDATE webhook_deliveries SET status = $2, attempts = $3, next_attempt_at = $4, last_error = $5,
+			response_code = $6, updated_at = $7, delivered_at = $8
+		WHERE id = $1`,
+		delivery.ID.Hex(), delivery.Status, delivery.Attempts, sqlTime(delivery.NextAttemptAt), delivery.LastError,
+		delivery.ResponseCode, sqlTime(delivery.UpdatedAt), sqlTime(delivery.DeliveredAt),
+	)
+	return err
+}
+
+func (r *SQLWebhookDeliveryRepository) Get(filter utils.KeyValue, delivery *entity.WebhookDelivery) error {
+	deliveries, err := r.find(filter, db.Query{Limit: 1})
+	if err != nil {
+		return err
+	}
+	if len(deliveries) == 0 {
+		return db.ErrNotFound
+	}
+	*delivery = *deliveries[0]
+	return nil
+}
+
+func (r *SQLWebhookDeliveryRepository) Find(filter utils.KeyValue, query db.Query) ([]*entity.WebhookDelivery, string, error) {
+	deliveries, err := r.find(filter, query)
+	if err != nil {
+		return nil, "", err
+	}
+	return sqlPage(deliveries, query, func(delivery *entity.WebhookDelivery) (interface{}, string) {
+		switch query.Sort {
+		case "nextAttemptAt":
+			return sqlTime(delivery.NextAttemptAt), delivery.ID.Hex()
+		case "createdAt":
+			return sqlTime(delivery.CreatedAt), delivery.ID.Hex()
+		case "updatedAt":
+			return sqlTime(delivery.UpdatedAt), delivery.ID.Hex()
+		}
+		return delivery.ID.Hex(), delivery.ID.Hex()
+	})
+}
+
+func (r *SQLWebhookDeliveryRepository) Claim(id primitive.ObjectID, due time.Time, until time.Time) (bool, error) {
+	result, err := r.DB.Exec(
+		`UPDATE webhook_deliveries SET next_attempt_at = $4 WHERE id = $1 AND status = $2 AND next_attempt_at = $3`,
+		id.Hex(), entity.DeliveryPending, sqlTime(due), sqlTime(until),
+	)
+	if err != nil {
+		return false, err
+	}
+	claimed, err := result.RowsAffected()
+	return claimed > 0, err
+}
+
+func (r *SQLWebhookDeliveryRepository) find(filter utils.KeyValue, query db.Query) ([]*entity.WebhookDelivery, error) {
+	clauses, args, err := sqlSelect(filter, query, deliveryColumns)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.DB.Query(`SELECT id, webhook_id, event_id, dedup_key, event_type, payload, status, attempts,
+		next_attempt_at, last_error, response_code, created_at, updated_at, delivered_at
+		FROM webhook_deliveries`+clauses, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := make([]*entity.WebhookDelivery, 0)
+	for rows.Next() {
+		var (
+			delivery                                         entity.WebhookDelivery
+			id, webhookID                                    string
+			nextAttemptAt, createdAt, updatedAt, deliveredAt int64
+		)
+		err := rows.Scan(&id, &webhookID, &delivery.EventID, &delivery.DedupKey, &delivery.EventType, &delivery.Payload,
+			&delivery.Status, &delivery.Attempts, &nextAttemptAt, &delivery.LastError, &delivery.ResponseCode,
+			&createdAt, &updatedAt, &deliveredAt)
+		if err != nil {
+			return nil, err
+		}
+		delivery.ID, _ = primitive.ObjectIDFromHex(id)
+		delivery.WebhookID, _ = primitive.ObjectIDFromHex(webhookID)
+		delivery.NextAttemptAt = timeFromSQL(nextAttemptAt)
+		delivery.CreatedAt = timeFromSQL(createdAt)
+		delivery.UpdatedAt = timeFromSQL(updatedAt)
+		delivery.DeliveredAt = timeFromSQL(deliveredAt)
+		deliveries = append(deliveries, &delivery)
+	}
+	return deliveries, rows.Err()
+}
+
+// NewSQLRepositories builds every repository on one SQL connection pool.
+// The schema must be migrated with MigrateSQL first.
+func NewSQLRepositories(conn *sql.DB) *Repositories {
+	feed := db.NewChangeFeed()
+	repos := sqlRepositories(conn, feed)
+	repos.transaction = func(fn func(tx *Repositories) error) error {
+		return sqlTransaction(conn, feed, fn)
+	}
+	return repos
+}
+
+// sqlTransaction runs fn with repositories that share one transaction.
+func sqlTransaction(conn SQLConn, feed *db.ChangeFeed, fn func(tx *Repositories) error) error {
+	// The changes of the transaction reach watchers once it commits.
+	held := feed.Hold()
+	err := inTx(conn, func(tx SQLConn) error {
+		repos := sqlRepositories(tx, held)
+		// A transaction started within this one joins it.
+		repos.transaction = func(fn func(tx *Repositories) error) error {
+			return fn(repos)
+		}
+		return fn(repos)
+	})
+	if err != nil {
+		return err
+	}
+	return held.Release()
+}
+
+func sqlRepositories(conn SQLConn, feed *db.ChangeFeed) *Repositories {
+	return &Repositories{
+		Orders:     NewSQLOrderRepository(conn, feed),
+		Customers:  NewSQLCustomerRepository(conn),
+		Products:   NewSQLProductRepository(conn),
+		Sequences:  NewSQLSequenceRepository(conn),
+		Outbox:     NewSQLOutboxRepository(conn),
+		Webhooks:   NewSQLWebhookRepository(conn),
+		Deliveries: NewSQLWebhookDeliveryRepository(conn),
+	}
+}
+
+func sqlCount(conn SQLConn, table string, filter utils.KeyValue, columns map[string]string) (int64, error) {
+	conditions, args, err := sqlConditions(filter, columns)
+	if err != nil {
+		return 0, err
+	}
+	query := `SELECT COUNT(*) FROM ` + table
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	var n int64
+	err = conn.QueryRow(query, args...).Scan(&n)
+	return n, err
+}
+
+// sqlError maps unique constraint violations from either driver to
+// db.ErrDuplicateKey.
+func sqlError(err error) error {
+	if err == nil {
+		return nil
+	}
+	message := err.Error()
+	if strings.Contains(message, "duplicate key") || strings.Contains(message, "UNIQUE constraint failed") {
+		return db.ErrDuplicateKey
+	}
+	return err
+}
@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// sqlMigrations is the SQL schema, one entry per version. Entries are only
+// ever appended; an applied migration must never change.
+var sqlMigrations = []string{
+	// 1: customers, products and orders with normalized order items.
+	`CREATE TABLE customers (
+		id TEXT PRIMARY KEY,
+		name TEXT NOT NULL,
+		lat DOUBLE PRECISION NOT NULL,
+		lon DOUBLE PRECISION NOT NULL,
+		created_at BIGINT NOT NULL DEFAULT 0
+	);
+	CREATE TABLE products (
+		id TEXT PRIMARY KEY,
+		name TEXT NOT NULL,
+		description TEXT NOT NULL DEFAULT '',
+		price DOUBLE PRECISION NOT NULL DEFAULT 0,
+		sku TEXT NOT NULL DEFAULT '',
+		created_at BIGINT NOT NULL DEFAULT 0
+	);
+	CREATE TABLE orders (
+		id TEXT PRIMARY KEY,
+		status TEXT NOT NULL DEFAULT '',
+		order_no TEXT NOT NULL DEFAULT '',
+		customer_id TEXT NOT NULL DEFAULT '',
+		delivery_date BIGINT NOT NULL DEFAULT 0,
+		created_at BIGINT NOT NULL DEFAULT 0
+	);
+	CREATE INDEX orders_customer_id ON orders (customer_id);
+	CREATE TABLE order_items (
+		order_id TEXT NOT NULL REFERENCES orders (id),
+		position INTEGER NOT NULL,
+		product_id TEXT NOT NULL,
+		name TEXT NOT NULL,
+		description TEXT NOT NULL DEFAULT '',
+		price DOUBLE PRECISION NOT NULL DEFAULT 0,
+		sku TEXT NOT NULL DEFAULT '',
+		created_at BIGINT NOT NULL DEFAULT 0,
+		PRIMARY KEY (order_id, position)
+	);`,
+	// 2: archivable catalog products with unique SKUs.
+	`ALTER TABLE products ADD COLUMN archived BOOLEAN NOT NULL DEFAULT FALSE;
+	CREATE UNIQUE INDEX products_sku ON products (sku) WHERE sku <> '';`,
+	// 3: amounts in minor units with a currency, order line quantities and
+	// order totals. The float price columns stay until cmd/migrate has
+	// converted them; rows it has not converted yet have no currency.
+	`ALTER TABLE products ADD COLUMN price_amount BIGINT NOT NULL DEFAULT 0;
+	ALTER TABLE products ADD COLUMN currency TEXT NOT NULL DEFAULT '';
+	ALTER TABLE order_items ADD COLUMN quantity BIGINT NOT NULL DEFAULT 1;
+	ALTER TABLE order_items ADD COLUMN unit_price BIGINT NOT NULL DEFAULT 0;
+	ALTER TABLE order_items ADD COLUMN line_total BIGINT NOT NULL DEFAULT 0;
+	ALTER TABLE orders ADD COLUMN currency TEXT NOT NULL DEFAULT '';
+	ALTER TABLE orders ADD COLUMN subtotal BIGINT NOT NULL DEFAULT 0;
+	ALTER TABLE orders ADD COLUMN tax BIGINT NOT NULL DEFAULT 0;
+	ALTER TABLE orders ADD COLUMN shipping BIGINT NOT NULL DEFAULT 0;
+	ALTER TABLE orders ADD COLUMN total BIGINT NOT NULL DEFAULT 0;`,
+	// 4: named sequences for order numbers and unique order numbers.
+	// Numbers that were already taken twice keep the oldest row and get the
+	// order id appended on the others.
+	`CREATE TABLE sequences (
+		name TEXT PRIMARY KEY,
+		value BIGINT NOT NULL
+	);
+	UPDATE orders SET order_no = order_no || '-' || id
+	WHERE order_no <> '' AND id NOT IN (
+		SELECT MIN(id) FROM orders WHERE order_no <> '' GROUP BY order_no
+	);
+	CREATE UNIQUE INDEX orders_order_no ON orders (order_no) WHERE order_no <> '';`,
+	// 5: times in unix milliseconds instead of seconds, and update times.
+	`UPDATE orders SET delivery_date = delivery_date * 1000, created_at = created_at * 1000;
+	UPDATE order_items SET created_at = created_at * 1000;
+	UPDATE customers SET created_at = created_at * 1000;
+	UPDATE products SET created_at = created_at * 1000;
+	ALTER TABLE orders ADD COLUMN updated_at BIGINT NOT NULL DEFAULT 0;
+	ALTER TABLE customers ADD COLUMN updated_at BIGINT NOT NULL DEFAULT 0;
+	ALTER TABLE products ADD COLUMN updated_at BIGINT NOT NULL DEFAULT 0;
+	UPDATE orders SET updated_at = created_at;
+	UPDATE customers SET updated_at = created_at;
+	UPDATE products SET updated_at = created_at;`,
+	// 6: the outbox of messages to publish, written with the changes.
+	`CREATE TABLE outbox (
+		id TEXT PRIMARY KEY,
+		topic TEXT NOT NULL,
+		record_key TEXT NOT NULL,
+		payload TEXT NOT NULL,
+		created_at BIGINT NOT NULL,
+		published_at BIGINT NOT NULL DEFAULT 0,
+		attempts INTEGER NOT NULL DEFAULT 0,
+		last_error TEXT NOT NULL DEFAULT ''
+	);
+	CREATE INDEX outbox_pending ON outbox (published_at, id);`,
+	// 7: webhook subscriptions and their deliveries. Event types are
+	// stored comma separated.
+	`CREATE TABLE webhooks (
+		id TEXT PRIMARY KEY,
+		url TEXT NOT NULL,
+		event_types TEXT NOT NULL DEFAULT '',
+		secret TEXT NOT NULL,
+		disabled BOOLEAN NOT NULL DEFAULT FALSE,
+		created_at BIGINT NOT NULL,
+		updated_at BIGINT NOT NULL
+	);
+	CREATE TABLE webhook_deliveries (
+		id TEXT PRIMARY KEY,
+		webhook_id TEXT NOT NULL,
+		event_id TEXT NOT NULL,
+		dedup_key TEXT NOT NULL UNIQUE,
+		event_type TEXT NOT NULL,
+		payload TEXT NOT NULL,
+		status TEXT NOT NULL,
+		attempts INTEGER NOT NULL DEFAULT 0,
+		next_attempt_at BIGINT NOT NULL DEFAULT 0,
+		last_error TEXT NOT NULL DEFAULT '',
+		response_code INTEGER NOT NULL DEFAULT 0,
+		created_at BIGINT NOT NULL,
+		updated_at BIGINT NOT NULL,
+		delivered_at BIGINT NOT NULL DEFAULT 0
+	);
+	CREATE INDEX webhook_deliveries_due ON webhook_deliveries (status, next_attempt_at);`,
+}
+
+// MigrateSQL brings the schema up to date. Each pending migration runs in
+// its own transaction together with its schema_migrations row, so a failed
+// migration leaves the database at the previous version.
+func MigrateSQL(conn *sql.DB) error {
+	_, err := conn.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INTEGER PRIMARY KEY,
+		applied_at BIGINT NOT NULL
+	)`)
+	if err != nil {
+		return err
+	}
+
+	var current int
+	err = conn.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&current)
+	if err != nil {
+		return err
+	}
+
+	for i := current; i < len(sqlMigrations); i++ {
+		version := i + 1
+		err := inTx(conn, func(tx SQLConn) error {
+			if _, err := tx.Exec(sqlMigrations[i]); err != nil {
+				return err
+			}
+			_, err := tx.Exec(`INSERT INTO schema_migrations (version, applied_at) VALUES ($1, $2)`, version, time.Now().Unix())
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("repository: migration %d failed: %w", version, err)
+		}
+	}
+	return nil
+}
+
+// SQLConn runs the statements of the SQL repositories: a *sql.DB, or a
+// *sql.Tx when several repository calls must commit together.
+type SQLConn interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// inTx runs fn in a transaction, committing when it returns nil. Within a
+// transaction fn simply joins it.
+func inTx(conn SQLConn, fn func(tx SQLConn) error) error {
+	if observed, ok := conn.(*observedConn); ok {
+		return inTx(observed.conn, func(tx SQLConn) error {
+			return fn(&observedConn{conn: tx, observe: observed.observe})
+		})
+	}
+	db, ok := conn.(*sql.DB)
+	if !ok {
+		return fn(conn)
+	}
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"awesomeProject/pkg/db"
+	"database/sql"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// sqlTable finds the table a statement works on: the first one it reads
+// from, inserts into or updates.
+var sqlTable = regexp.MustCompile(`(?i)\b(?:from|into|update)\s+([a-z_]+)`)
+
+// observedConn reports the statements run on a SQLConn to a db.Observer,
+// with the statement verb as operation and its table as collection. Only
+// running the statement is timed, not reading the rows of a query.
+type observedConn struct {
+	conn    SQLConn
+	observe db.Observer
+}
+
+func (c *observedConn) done(query string, start time.Time, err error) {
+	operation, table := describeSQL(query)
+	c.observe(operation, table, time.Since(start), sqlError(err))
+}
+
+func (c *observedConn) Exec(query string, args ...interface{}) (sql.Result, error) {
+	start := time.Now()
+	result, err := c.conn.Exec(query, args...)
+	c.done(query, start, err)
+	return result, err
+}
+
+func (c *observedConn) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	start := time.Now()
+	rows, err := c.conn.Query(query, args...)
+	c.done(query, start, err)
+	return rows, err
+}
+
+func (c *observedConn) QueryRow(query string, args ...interface{}) *sql.Row {
+	start := time.Now()
+	row := c.conn.QueryRow(query, args...)
+	c.done(query, start, row.Err())
+	return row
+}
+
+// describeSQL returns the lower case verb of a statement and its table.
+func describeSQL(query string) (string, string) {
+	query = strings.TrimSpace(query)
+	operation := query
+	if i := strings.IndexFunc(query, func(r rune) bool { return r == ' ' || r == '\n' || r == '\t' }); i >= 0 {
+		operation = query[:i]
+	}
+	table := ""
+	if match := sqlTable.FindStringSubmatch(query); match != nil {
+		table = strings.ToLower(match[1])
+	}
+	return strings.ToLower(operation), table
+}
+
+// NewObservedSQLRepositories is NewSQLRepositories reporting every
+// statement, and every transaction as a whole, to observe.
+func NewObservedSQLRepositories(conn *sql.DB, observe db.Observer) *Repositories {
+	observed := &observedConn{conn: conn, observe: observe}
+	feed := db.NewChangeFeed()
+	repos := sqlRepositories(observed, feed)
+	repos.transaction = func(fn func(tx *Repositories) error) error {
+		start := time.Now()
+		err := sqlTransaction(observed, feed, fn)
+		observe("transaction", "", time.Since(start), err)
+		return err
+	}
+	return repos
+}
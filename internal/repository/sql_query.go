@@ -0,0 +1,197 @@
+package repository
+
+import (
+	"awesomeProject/pkg/db"
+	"awesomeProject/pkg/utils"
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+var sqlComparisons = map[string]string{
+	"$eq":  "=",
+	"$ne":  "<>",
+	"$gt":  ">",
+	"$gte": ">=",
+	"$lt":  "<",
+	"$lte": "<=",
+}
+
+// sqlSelect builds the WHERE, ORDER BY and LIMIT clauses of a page query.
+// Like the Mongo stores it fetches one row more than the page size so the
+// caller can tell whether there is a next page.
+func sqlSelect(filter utils.KeyValue, query db.Query, columns map[string]string) (string, []interface{}, error) {
+	conditions, args, err := sqlConditions(filter, columns)
+	if err != nil {
+		return "", nil, err
+	}
+
+	sortField := query.Sort
+	if sortField == "" {
+		sortField = "_id"
+	}
+	column, ok := columns[sortField]
+	if !ok {
+		return "", nil, fmt.Errorf("repository: cannot sort by %q", sortField)
+	}
+	op, direction := ">", "ASC"
+	if query.Descending {
+		op, direction = "<", "DESC"
+	}
+
+	value, id, after, err := query.After()
+	if err != nil {
+		return "", nil, err
+	}
+	if after {
+		args = append(args, value, id)
+		if column == "id" {
+			conditions = append(conditions, fmt.Sprintf("id %s $%d", op, len(args)))
+		} else {
+			conditions = append(conditions, fmt.Sprintf("(%[1]s %[2]s $%[3]d OR (%[1]s = $%[3]d AND id %[2]s $%[4]d))",
+				column, op, len(args)-1, len(args)))
+		}
+	}
+
+	clause := ""
+	if len(conditions) > 0 {
+		clause = " WHERE " + strings.Join(conditions, " AND ")
+	}
+	clause += fmt.Sprintf(" ORDER BY %s %s", column, direction)
+	if column != "id" {
+		clause += ", id " + direction
+	}
+	if query.Limit > 0 {
+		clause += fmt.Sprintf(" LIMIT %d", query.Limit+1)
+	}
+	return clause, args, nil
+}
+
+// sqlPage trims the extra row sqlSelect asked for and returns the token of
+// the next page. position returns the sort column value and id of a row.
+func sqlPage[T any](rows []T, query db.Query, position func(T) (interface{}, string)) ([]T, string, error) {
+	if query.Limit <= 0 || int64(len(rows)) <= query.Limit {
+		return rows, "", nil
+	}
+	rows = rows[:query.Limit]
+	value, id := position(rows[len(rows)-1])
+	token, err := query.NewPageToken(value, id)
+	return rows, token, err
+}
+
+// sqlConditions translates a filter on document fields into SQL conditions.
+// Values are either compared for equality or hold $eq, $ne, $gt, $gte, $lt,
+// $lte and $in operators.
+func sqlConditions(filter utils.KeyValue, columns map[string]string) ([]string, []interface{}, error) {
+	keys := make([]string, 0, len(filter))
+	for key := range filter {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var conditions []string
+	var args []interface{}
+	for _, key := range keys {
+		column, ok := columns[key]
+		if !ok {
+			return nil, nil, fmt.Errorf("repository: cannot filter by %q", key)
+		}
+
+		ops, isOperator := sqlOperators(filter[key])
+		if !isOperator {
+			args = append(args, sqlValue(filter[key]))
+			conditions = append(conditions, fmt.Sprintf("%s = $%d", column, len(args)))
+			continue
+		}
+
+		names := make([]string, 0, len(ops))
+		for op := range ops {
+			names = append(names, op)
+		}
+		sort.Strings(names)
+		for _, op := range names {
+			if op == "$in" {
+				values := reflect.ValueOf(ops[op])
+				if values.Kind() != reflect.Slice {
+					return nil, nil, fmt.Errorf("repository: $in on %q expects a list", key)
+				}
+				if values.Len() == 0 {
+					conditions = append(conditions, "1 = 0")
+					continue
+				}
+				for i := 0; i < values.Len(); i++ {
+					args = append(args, sqlValue(values.Index(i).Interface()))
+				}
+				conditions = append(conditions, fmt.Sprintf("%s IN (%s)", column, sqlPlaceholders(len(args)-values.Len()+1, values.Len())))
+				continue
+			}
+			comparison, ok := sqlComparisons[op]
+			if !ok {
+				return nil, nil, fmt.Errorf("repository: unsupported operator %s on %q", op, key)
+			}
+			args = append(args, sqlValue(ops[op]))
+			conditions = append(conditions, fmt.Sprintf("%s %s $%d", column, comparison, len(args)))
+		}
+	}
+	return conditions, args, nil
+}
+
+// sqlOperators returns the operator document of a filter value.
+func sqlOperators(v interface{}) (map[string]interface{}, bool) {
+	var ops map[string]interface{}
+	switch value := v.(type) {
+	case primitive.M:
+		ops = value
+	case utils.KeyValue:
+		ops = value
+	case map[string]interface{}:
+		ops = value
+	default:
+		return nil, false
+	}
+	for op := range ops {
+		if !strings.HasPrefix(op, "$") {
+			return nil, false
+		}
+	}
+	return ops, len(ops) > 0
+}
+
+// sqlValue converts the Mongo types used in filters to column values.
+func sqlValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case primitive.ObjectID:
+		return value.Hex()
+	case time.Time:
+		return sqlTime(value)
+	case primitive.DateTime:
+		return int64(value)
+	}
+	return v
+}
+
+// sqlTime stores times as unix milliseconds, 0 for the zero time.
+func sqlTime(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMilli()
+}
+
+func timeFromSQL(millis int64) time.Time {
+	if millis == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(millis).UTC()
+}
+
+func sqlPlaceholders(first int, n int) string {
+	placeholders := make([]string, n)
+	for i := range placeholders {
+		placeholders[i] = fmt.Sprintf("$%d", first+i)
+	}
+	return strings.Join(placeholders, ", ")
+}
@@ -0,0 +1,317 @@
+package repository
+
+import (
+	"awesomeProject/internal/entity"
+	"awesomeProject/pkg/db"
+	"awesomeProject/pkg/money"
+	"awesomeProject/pkg/utils"
+	"database/sql"
+	"errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	_ "modernc.org/sqlite"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// openSQLite returns a migrated in-memory SQLite database.
+func openSQLite(t *testing.T) *sql.DB {
+	conn, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Every connection would open a database of its own.
+	conn.SetMaxOpenConns(1)
+	t.Cleanup(func() { conn.Close() })
+	if err := MigrateSQL(conn); err != nil {
+		t.Fatalf("MigrateSQL: %v", err)
+	}
+	return conn
+}
+
+// eachBackend runs test against the SQL repositories and, as the reference
+// for their semantics, the document repositories on a MemoryStore.
+func eachBackend(t *testing.T, test func(t *testing.T, repos *Repositories)) {
+	t.Run("sqlite", func(t *testing.T) {
+		test(t, NewSQLRepositories(openSQLite(t)))
+	})
+	t.Run("document", func(t *testing.T) {
+		store := db.NewMemoryStore()
+		if err := EnsureMongoIndexes(store); err != nil {
+			t.Fatal(err)
+		}
+		test(t, NewMongoRepositories(store))
+	})
+}
+
+func newTestOrder(orderNo string) *entity.Order {
+	at := time.Now().UTC().Truncate(time.Millisecond)
+	return &entity.Order{
+		ID:         primitive.NewObjectID(),
+		Status:     entity.Processing,
+		OrderNo:    orderNo,
+		CustomerId: primitive.NewObjectID(),
+		Items: []entity.OrderLine{
+			{ProductID: primitive.NewObjectID(), Name: "Widget", SKU: "WID-001", Quantity: 2,
+				UnitPrice: money.New(250, "EUR"), LineTotal: money.New(500, "EUR"), CreatedAt: at},
+			{ProductID: primitive.NewObjectID(), Name: "Gadget", Description: "Small", SKU: "GAD-002", Quantity: 1,
+				UnitPrice: money.New(999, "EUR"), LineTotal: money.New(999, "EUR"), CreatedAt: at},
+		},
+		DeliveryDate: at.Add(48 * time.Hour),
+		Subtotal:     money.New(1499, "EUR"),
+		Tax:          money.New(285, "EUR"),
+		Shipping:     money.New(0, "EUR"),
+		Total:        money.New(1784, "EUR"),
+	}
+}
+
+func getOrder(t *testing.T, repos *Repositories, id primitive.ObjectID) *entity.Order {
+	order, err := entity.NewOrder().Get(repos.Orders, utils.KeyValue{"_id": id})
+	if err != nil {
+		t.Fatalf("Get %s: %v", id.Hex(), err)
+	}
+	return order
+}
+
+func TestMigrateSQLTwice(t *testing.T) {
+	conn := openSQLite(t)
+	if err := MigrateSQL(conn); err != nil {
+		t.Fatalf("second MigrateSQL: %v", err)
+	}
+	var versions, latest int
+	if err := conn.QueryRow(`SELECT COUNT(*), MAX(version) FROM schema_migrations`).Scan(&versions, &latest); err != nil {
+		t.Fatal(err)
+	}
+	if versions != len(sqlMigrations) || latest != len(sqlMigrations) {
+		t.Errorf("schema_migrations has %d rows up to version %d, want %d", versions, latest, len(sqlMigrations))
+	}
+}
+
+func TestOrderInsertGetReplace(t *testing.T) {
+	eachBackend(t, func(t *testing.T, repos *Repositories) {
+		order := newTestOrder("ORD-1")
+		if _, err := order.Persist(repos.Orders); err != nil {
+			t.Fatalf("insert: %v", err)
+		}
+		if got := getOrder(t, repos, order.ID); !reflect.DeepEqual(got, order) {
+			t.Errorf("stored order differs\n got: %+v\nwant: %+v", got, order)
+		}
+
+		order.Items = order.Items[1:]
+		order.Items[0].Quantity = 3
+		order.Total = money.New(2997, "EUR")
+		if _, err := order.Persist(repos.Orders); err != nil {
+			t.Fatalf("replace: %v", err)
+		}
+		if got := getOrder(t, repos, order.ID); !reflect.DeepEqual(got, order) {
+			t.Errorf("replaced order differs\n got: %+v\nwant: %+v", got, order)
+		}
+
+		err := repos.Orders.Get(utils.KeyValue{"_id": primitive.NewObjectID()}, entity.NewOrder())
+		if !errors.Is(err, db.ErrNotFound) {
+			t.Errorf("Get of a missing order = %v, want db.ErrNotFound", err)
+		}
+	})
+}
+
+func TestOrderReplaceAfterStatusChange(t *testing.T) {
+	eachBackend(t, func(t *testing.T, repos *Repositories) {
+		order := newTestOrder("")
+		if _, err := order.Persist(repos.Orders); err != nil {
+			t.Fatal(err)
+		}
+		stale := getOrder(t, repos, order.ID)
+		if err := order.UpdateStatus(repos.Orders, entity.Cancelled); err != nil {
+			t.Fatal(err)
+		}
+
+		stale.Items[0].Quantity = 5
+		if _, err := stale.Persist(repos.Orders); !errors.Is(err, entity.ErrStatusChanged) {
+			t.Errorf("replacing a stale order = %v, want entity.ErrStatusChanged", err)
+		}
+		got := getOrder(t, repos, order.ID)
+		if got.Status != entity.Cancelled || got.Items[0].Quantity != 2 {
+			t.Errorf("stale replace wrote the order: %s with quantity %d", got.Status, got.Items[0].Quantity)
+		}
+	})
+}
+
+func TestOrderReplaceMissing(t *testing.T) {
+	eachBackend(t, func(t *testing.T, repos *Repositories) {
+		order := newTestOrder("")
+		if err := repos.Orders.Replace(order); !errors.Is(err, db.ErrNotFound) {
+			t.Errorf("replacing a missing order = %v, want db.ErrNotFound", err)
+		}
+	})
+}
+
+func TestOrderUpdateStatusConflicts(t *testing.T) {
+	eachBackend(t, func(t *testing.T, repos *Repositories) {
+		order := newTestOrder("")
+		if _, err := order.Persist(repos.Orders); err != nil {
+			t.Fatal(err)
+		}
+		at := time.Now().UTC().Truncate(time.Millisecond)
+
+		matched, err := repos.Orders.UpdateStatus(order.ID, entity.Processing, entity.Transit, at)
+		if err != nil || matched != 1 {
+			t.Fatalf("first UpdateStatus = %d, %v; want 1 match", matched, err)
+		}
+		matched, err = repos.Orders.UpdateStatus(order.ID, entity.Processing, entity.Cancelled, at)
+		if err != nil || matched != 0 {
+			t.Fatalf("UpdateStatus from a stale status = %d, %v; want no match", matched, err)
+		}
+		if got := getOrder(t, repos, order.ID); got.Status != entity.Transit || !got.UpdatedAt.Equal(at) {
+			t.Errorf("order is %s updated at %s, want transit at %s", got.Status, got.UpdatedAt, at)
+		}
+
+		// order still holds the status it was read with.
+		if err := order.UpdateStatus(repos.Orders, entity.Cancelled); !errors.Is(err, entity.ErrStatusChanged) {
+			t.Errorf("UpdateStatus of a stale order = %v, want entity.ErrStatusChanged", err)
+		}
+	})
+}
+
+func TestOrderNoIsUnique(t *testing.T) {
+	eachBackend(t, func(t *testing.T, repos *Repositories) {
+		if _, err := newTestOrder("ORD-7").Persist(repos.Orders); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := newTestOrder("ORD-7").Persist(repos.Orders); !errors.Is(err, db.ErrDuplicateKey) {
+			t.Errorf("second order ORD-7 = %v, want db.ErrDuplicateKey", err)
+		}
+		// Orders without a number do not collide.
+		for i := 0; i < 2; i++ {
+			if _, err := newTestOrder("").Persist(repos.Orders); err != nil {
+				t.Errorf("order without number %d: %v", i, err)
+			}
+		}
+	})
+}
+
+func TestOrderGetAllFilters(t *testing.T) {
+	eachBackend(t, func(t *testing.T, repos *Repositories) {
+		customer := primitive.NewObjectID()
+		inserted := map[primitive.ObjectID]bool{}
+		for i := 0; i < 3; i++ {
+			order := newTestOrder("")
+			order.CustomerId = customer
+			if _, err := order.Persist(repos.Orders); err != nil {
+				t.Fatal(err)
+			}
+			inserted[order.ID] = true
+		}
+		if _, err := newTestOrder("").Persist(repos.Orders); err != nil {
+			t.Fatal(err)
+		}
+
+		orders, err := repos.Orders.GetAll(utils.KeyValue{"customerId": customer})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(orders) != 3 {
+			t.Errorf("GetAll returned %d orders, want 3", len(orders))
+		}
+		for _, order := range orders {
+			if !inserted[order.ID] || len(order.Items) != 2 {
+				t.Errorf("GetAll returned %s with %d items", order.ID.Hex(), len(order.Items))
+			}
+		}
+	})
+}
+
+func TestOrderFindPages(t *testing.T) {
+	eachBackend(t, func(t *testing.T, repos *Repositories) {
+		inserted := map[primitive.ObjectID]bool{}
+		for i := 0; i < 5; i++ {
+			order := newTestOrder("")
+			if _, err := order.Persist(repos.Orders); err != nil {
+				t.Fatal(err)
+			}
+			inserted[order.ID] = true
+		}
+		other := newTestOrder("")
+		other.Status = entity.Transit
+		if _, err := other.Persist(repos.Orders); err != nil {
+			t.Fatal(err)
+		}
+
+		filter := utils.KeyValue{"status": entity.Processing}
+		query := db.Query{Sort: "createdAt", Limit: 2}
+		seen := map[primitive.ObjectID]bool{}
+		var sizes []int
+		var last time.Time
+		for page := 0; ; page++ {
+			if page > 5 {
+				t.Fatal("paging does not end")
+			}
+			orders, next, err := repos.Orders.Find(filter, query)
+			if err != nil {
+				t.Fatalf("Find page %d: %v", page, err)
+			}
+			sizes = append(sizes, len(orders))
+			for _, order := range orders {
+				if seen[order.ID] || !inserted[order.ID] {
+					t.Errorf("page %d returned %s again or from outside the filter", page, order.ID.Hex())
+				}
+				if order.CreatedAt.Before(last) {
+					t.Errorf("page %d is out of createdAt order", page)
+				}
+				last = order.CreatedAt
+				seen[order.ID] = true
+				if len(order.Items) != 2 {
+					t.Errorf("order %s came with %d items, want 2", order.ID.Hex(), len(order.Items))
+				}
+			}
+			if next == "" {
+				break
+			}
+			query.PageToken = next
+		}
+		if !reflect.DeepEqual(sizes, []int{2, 2, 1}) || len(seen) != 5 {
+			t.Errorf("pages of %v with %d orders, want [2 2 1] with 5", sizes, len(seen))
+		}
+
+		count, err := repos.Orders.Count(filter)
+		if err != nil || count != 5 {
+			t.Errorf("Count = %d, %v; want 5", count, err)
+		}
+
+		_, _, err = repos.Orders.Find(filter, db.Query{Sort: "createdAt", PageToken: "not-a-token"})
+		if !errors.Is(err, db.ErrInvalidPageToken) {
+			t.Errorf("Find with a bad token = %v, want db.ErrInvalidPageToken", err)
+		}
+	})
+}
+
+func TestSQLNestedTransaction(t *testing.T) {
+	repos := NewSQLRepositories(openSQLite(t))
+	persistNested := func(order *entity.Order, outcome error) error {
+		return repos.Transaction(func(tx *Repositories) error {
+			err := tx.Transaction(func(nested *Repositories) error {
+				_, err := order.Persist(nested.Orders)
+				return err
+			})
+			if err != nil {
+				return err
+			}
+			return outcome
+		})
+	}
+
+	committed := newTestOrder("")
+	if err := persistNested(committed, nil); err != nil {
+		t.Fatalf("nested transaction: %v", err)
+	}
+	getOrder(t, repos, committed.ID)
+
+	rolledBack := newTestOrder("")
+	failure := errors.New("abort")
+	if err := persistNested(rolledBack, failure); !errors.Is(err, failure) {
+		t.Fatalf("failing transaction = %v, want %v", err, failure)
+	}
+	err := repos.Orders.Get(utils.KeyValue{"_id": rolledBack.ID}, entity.NewOrder())
+	if !errors.Is(err, db.ErrNotFound) {
+		t.Errorf("order of a rolled back transaction = %v, want db.ErrNotFound", err)
+	}
+}
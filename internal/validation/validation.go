@@ -0,0 +1,133 @@
+package validation
+
+import (
+	"awesomeProject/internal/errs"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var validate = newValidator()
+
+// newValidator checks entity validate tags. Fields are reported by their
+// json name so violations line up with the proto field names, and object
+// ids are converted so the standard tags apply to them.
+func newValidator() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+	v.RegisterCustomTypeFunc(func(field reflect.Value) interface{} {
+		if id := field.Interface().(primitive.ObjectID); !id.IsZero() {
+			return id.Hex()
+		}
+		return ""
+	}, primitive.ObjectID{})
+	_ = v.RegisterValidation("future", func(fl validator.FieldLevel) bool {
+		t, ok := fl.Field().Interface().(time.Time)
+		return ok && t.After(time.Now())
+	})
+	return v
+}
+
+// descriptions words the failed tags for clients; %s is the tag parameter.
+var descriptions = map[string]string{
+	"required":  "is required",
+	"min":       "must have at least %s entries",
+	"gt":        "must be greater than %s",
+	"gte":       "must be at least %s",
+	"lte":       "must be at most %s",
+	"len":       "must be %s characters long",
+	"alpha":     "must contain only letters",
+	"uppercase": "must be upper case",
+	"oneof":     "must be one of %s",
+	"future":    "must be in the future",
+	"latitude":  "must be a latitude between -90 and 90",
+	"longitude": "must be a longitude between -180 and 180",
+}
+
+// Violations collects every problem with a request so clients can fix them
+// all at once.
+type Violations []errs.FieldViolation
+
+// Add records a violation on field. Only the first violation per field is
+// kept, and none for a field whose elements already have one.
+func (v *Violations) Add(field string, format string, args ...interface{}) {
+	for _, existing := range *v {
+		if existing.Field == field || strings.HasPrefix(existing.Field, field+"[") || strings.HasPrefix(existing.Field, field+".") {
+			return
+		}
+	}
+	*v = append(*v, errs.FieldViolation{Field: field, Description: fmt.Sprintf(format, args...)})
+}
+
+// ObjectID parses a hex id, recording a violation when it is not one.
+func (v *Violations) ObjectID(field string, hex string) primitive.ObjectID {
+	id, err := primitive.ObjectIDFromHex(hex)
+	if err != nil {
+		v.Add(field, "%q is not a valid id", hex)
+	}
+	return id
+}
+
+// Time converts a proto timestamp, recording a violation when it is out of
+// range. An unset timestamp is the zero time, which required tags report.
+func (v *Violations) Time(field string, ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	if err := ts.CheckValid(); err != nil {
+		v.Add(field, "is not a valid timestamp")
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
+// Struct checks the validate tags of entity, reporting its fields under
+// prefix.
+func (v *Violations) Struct(prefix string, entity interface{}) {
+	err := validate.Struct(entity)
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return
+	}
+	for _, fieldErr := range validationErrs {
+		field := fieldErr.Namespace()
+		// Drop the struct name the namespace starts with.
+		if i := strings.Index(field, "."); i >= 0 {
+			field = field[i+1:]
+		}
+		if prefix != "" {
+			field = prefix + "." + field
+		}
+		description, ok := descriptions[fieldErr.Tag()]
+		if !ok {
+			description = "failed the " + fieldErr.Tag() + " check"
+		}
+		if strings.Contains(description, "%s") {
+			description = fmt.Sprintf(description, fieldErr.Param())
+		}
+		v.Add(field, "%s", description)
+	}
+}
+
+// Err returns the collected violations as an invalid argument error, nil
+// when there are none.
+func (v Violations) Err() error {
+	if len(v) == 0 {
+		return nil
+	}
+	if len(v) == 1 {
+		return errs.NewInvalidArgument(v[0].Field+": "+v[0].Description, v...)
+	}
+	return errs.NewInvalidArgument(fmt.Sprintf("request has %d invalid fields", len(v)), v...)
+}
@@ -0,0 +1,83 @@
+// Package webhooks delivers order events to partner endpoints.
+//
+// The outbox relay hands every order event to a Dispatcher, which queues a
+// delivery for each webhook subscribed to its type. A Worker POSTs the due
+// deliveries, signed with the webhook secret, and retries failed ones with
+// exponential backoff. Deliveries that run out of attempts become dead
+// letters, which the Webhooks API lists and replays.
+package webhooks
+
+import (
+	"awesomeProject/internal/entity"
+	"awesomeProject/internal/events"
+	"awesomeProject/internal/outbox"
+	"awesomeProject/internal/repository"
+	"awesomeProject/pkg/db"
+	"awesomeProject/pkg/utils"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"time"
+)
+
+// Payload is the JSON body POSTed to webhooks.
+type Payload struct {
+	// ID is the event id, the same for every attempt and replay.
+	ID        string      `json:"id"`
+	Type      events.Type `json:"type"`
+	CreatedAt time.Time   `json:"createdAt"`
+	// Data is the order event as written to the outbox.
+	Data json.RawMessage `json:"data"`
+}
+
+// Dispatcher is the outbox.Publisher that queues webhook deliveries.
+type Dispatcher struct {
+	log        *logrus.Logger
+	webhooks   repository.WebhookRepository
+	deliveries repository.WebhookDeliveryRepository
+}
+
+func NewDispatcher(log *logrus.Logger, repos *repository.Repositories) *Dispatcher {
+	return &Dispatcher{log: log, webhooks: repos.Webhooks, deliveries: repos.Deliveries}
+}
+
+// Publish queues the order event of a record for the webhooks subscribed to
+// its type, once per webhook however often the record is published.
+// Records of other topics are ignored.
+func (d *Dispatcher) Publish(ctx context.Context, record *entity.OutboxRecord) error {
+	if record.Topic != outbox.OrdersTopic {
+		return nil
+	}
+	var event events.Event
+	if err := json.Unmarshal([]byte(record.Payload), &event); err != nil {
+		d.log.WithError(err).Errorf("Skipping undecodable outbox message %s", record.ID.Hex())
+		return nil
+	}
+	body, err := json.Marshal(Payload{
+		ID:        record.ID.Hex(),
+		Type:      event.Type,
+		CreatedAt: record.CreatedAt,
+		Data:      json.RawMessage(record.Payload),
+	})
+	if err != nil {
+		return err
+	}
+
+	webhooks, err := d.webhooks.GetAll(utils.KeyValue{"disabled": bson.M{"$ne": true}})
+	if err != nil {
+		return fmt.Errorf("webhooks: list webhooks: %w", err)
+	}
+	for _, webhook := range webhooks {
+		if !webhook.Subscribed(string(event.Type)) {
+			continue
+		}
+		delivery := entity.NewWebhookDelivery(webhook.ID, record.ID.Hex(), string(event.Type), string(body))
+		if err := d.deliveries.Insert(delivery); err != nil && !errors.Is(err, db.ErrDuplicateKey) {
+			return fmt.Errorf("webhooks: queue delivery to %s: %w", webhook.ID.Hex(), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,276 @@
+package webhooks
+
+import (
+	"awesomeProject/internal/entity"
+	"awesomeProject/internal/errs"
+	"awesomeProject/internal/mapper"
+	pb2 "awesomeProject/internal/orders/pb"
+	"awesomeProject/internal/repository"
+	"awesomeProject/internal/validation"
+	"awesomeProject/pkg/db"
+	"awesomeProject/pkg/utils"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"net/url"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 500
+)
+
+// WebhookServer manages the webhooks and their dead letters.
+type WebhookServer struct {
+	pb2.UnimplementedWebhooksServer
+	Log        *logrus.Logger
+	Webhooks   repository.WebhookRepository
+	Deliveries repository.WebhookDeliveryRepository
+}
+
+// CreateWebhook registers an endpoint and returns the secret its deliveries
+// are signed with. The secret cannot be read back later.
+func (s *WebhookServer) CreateWebhook(ctx context.Context, req *pb2.CreateWebhookReq) (*pb2.CreateWebhookRes, error) {
+	reqWebhook := req.GetWebhook()
+	if reqWebhook == nil {
+		return nil, errs.NewFieldViolation("webhook", "is required")
+	}
+	secret, err := NewSecret()
+	if err != nil {
+		return nil, errs.Wrap(err, "failed to create webhook secret")
+	}
+
+	var violations validation.Violations
+	webhook := entity.NewWebhook()
+	webhook.ID = primitive.NewObjectID()
+	webhook.URL = checkURL("webhook.url", reqWebhook.GetUrl(), &violations)
+	webhook.EventTypes = eventTypes(reqWebhook.GetEventTypes(), &violations)
+	webhook.Disabled = reqWebhook.GetDisabled()
+	webhook.Secret = secret
+	violations.Struct("webhook", webhook)
+	if err := violations.Err(); err != nil {
+		return nil, err
+	}
+
+	if _, err := webhook.Persist(s.Webhooks); err != nil {
+		return nil, errs.Wrap(err, "failed to create webhook")
+	}
+	return &pb2.CreateWebhookRes{Webhook: mapper.WebhookToProto(webhook), Secret: secret}, nil
+}
+
+func (s *WebhookServer) GetWebhook(ctx context.Context, req *pb2.GetWebhookReq) (*pb2.GetWebhookRes, error) {
+	var violations validation.Violations
+	id := violations.ObjectID("id", req.GetId())
+	if err := violations.Err(); err != nil {
+		return nil, err
+	}
+
+	webhook, err := s.getWebhook(id)
+	if err != nil {
+		return nil, err
+	}
+	return &pb2.GetWebhookRes{Webhook: mapper.WebhookToProto(webhook)}, nil
+}
+
+// ListWebhooks returns one page of webhooks, oldest first. Clients pass
+// nextPageToken back as pageToken until it comes back empty.
+func (s *WebhookServer) ListWebhooks(ctx context.Context, req *pb2.ListWebhooksReq) (*pb2.ListWebhooksRes, error) {
+	query, err := pageQuery(req.GetPageSize(), req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+	webhooks, next, err := s.Webhooks.Find(utils.KeyValue{}, query)
+	if errors.Is(err, db.ErrInvalidPageToken) {
+		return nil, errs.NewFieldViolation("pageToken", "is invalid or belongs to a different query")
+	}
+	if err != nil {
+		return nil, errs.Wrap(err, "failed to list webhooks")
+	}
+
+	res := &pb2.ListWebhooksRes{Webhooks: make([]*pb2.Webhook, 0, len(webhooks)), NextPageToken: next}
+	for _, webhook := range webhooks {
+		res.Webhooks = append(res.Webhooks, mapper.WebhookToProto(webhook))
+	}
+	return res, nil
+}
+
+// UpdateWebhook replaces the url, event types and disabled flag of a
+// webhook. Deliveries already queued go to the new url.
+func (s *WebhookServer) UpdateWebhook(ctx context.Context, req *pb2.UpdateWebhookReq) (*pb2.UpdateWebhookRes, error) {
+	reqWebhook := req.GetWebhook()
+	if reqWebhook == nil {
+		return nil, errs.NewFieldViolation("webhook", "is required")
+	}
+	var violations validation.Violations
+	id := violations.ObjectID("webhook.id", reqWebhook.GetId())
+	webhookURL := checkURL("webhook.url", reqWebhook.GetUrl(), &violations)
+	types := eventTypes(reqWebhook.GetEventTypes(), &violations)
+	if err := violations.Err(); err != nil {
+		return nil, err
+	}
+
+	webhook, err := s.getWebhook(id)
+	if err != nil {
+		return nil, err
+	}
+	webhook.URL = webhookURL
+	webhook.EventTypes = types
+	webhook.Disabled = reqWebhook.GetDisabled()
+	violations.Struct("webhook", webhook)
+	if err := violations.Err(); err != nil {
+		return nil, err
+	}
+	if _, err := webhook.Persist(s.Webhooks); err != nil {
+		return nil, errs.Wrap(err, "failed to update webhook")
+	}
+	return &pb2.UpdateWebhookRes{Webhook: mapper.WebhookToProto(webhook)}, nil
+}
+
+func (s *WebhookServer) DeleteWebhook(ctx context.Context, req *pb2.DeleteWebhookReq) (*pb2.DeleteWebhookRes, error) {
+	var violations validation.Violations
+	id := violations.ObjectID("id", req.GetId())
+	if err := violations.Err(); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.getWebhook(id); err != nil {
+		return nil, err
+	}
+	if err := s.Webhooks.Delete(id); err != nil {
+		return nil, errs.Wrap(err, "failed to delete webhook")
+	}
+	return &pb2.DeleteWebhookRes{}, nil
+}
+
+// ListDeadLetters returns one page of dead deliveries, oldest first.
+func (s *WebhookServer) ListDeadLetters(ctx context.Context, req *pb2.ListDeadLettersReq) (*pb2.ListDeadLettersRes, error) {
+	var violations validation.Violations
+	filter := utils.KeyValue{"status": entity.DeliveryDead}
+	if req.GetWebhookId() != "" {
+		filter["webhookId"] = violations.ObjectID("webhookId", req.GetWebhookId())
+	}
+	if err := violations.Err(); err != nil {
+		return nil, err
+	}
+	query, err := pageQuery(req.GetPageSize(), req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries, next, err := s.Deliveries.Find(filter, query)
+	if errors.Is(err, db.ErrInvalidPageToken) {
+		return nil, errs.NewFieldViolation("pageToken", "is invalid or belongs to a different query")
+	}
+	if err != nil {
+		return nil, errs.Wrap(err, "failed to list dead letters")
+	}
+
+	res := &pb2.ListDeadLettersRes{Deliveries: make([]*pb2.WebhookDelivery, 0, len(deliveries)), NextPageToken: next}
+	for _, delivery := range deliveries {
+		res.Deliveries = append(res.Deliveries, mapper.DeliveryToProto(delivery))
+	}
+	return res, nil
+}
+
+// ReplayDelivery queues a finished delivery again. The webhook must still
+// exist and be enabled.
+func (s *WebhookServer) ReplayDelivery(ctx context.Context, req *pb2.ReplayDeliveryReq) (*pb2.ReplayDeliveryRes, error) {
+	var violations validation.Violations
+	id := violations.ObjectID("id", req.GetId())
+	if err := violations.Err(); err != nil {
+		return nil, err
+	}
+
+	delivery := &entity.WebhookDelivery{}
+	err := s.Deliveries.Get(utils.KeyValue{"_id": id}, delivery)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, errs.NewNotFound("delivery %s not found", id.Hex())
+	}
+	if err != nil {
+		return nil, errs.Wrap(err, "failed to get delivery")
+	}
+	if delivery.Status == entity.DeliveryPending {
+		return nil, errs.NewFailedPrecondition("delivery %s is still pending", id.Hex())
+	}
+	webhook, err := s.getWebhook(delivery.WebhookID)
+	if err != nil {
+		return nil, err
+	}
+	if webhook.Disabled {
+		return nil, errs.NewFailedPrecondition("webhook %s is disabled", webhook.ID.Hex())
+	}
+
+	delivery.Replay()
+	if err := s.Deliveries.Replace(delivery); err != nil {
+		return nil, errs.Wrap(err, "failed to replay delivery")
+	}
+	return &pb2.ReplayDeliveryRes{Delivery: mapper.DeliveryToProto(delivery)}, nil
+}
+
+// getWebhook loads a webhook by id, reporting a missing one as not found.
+func (s *WebhookServer) getWebhook(id primitive.ObjectID) (*entity.Webhook, error) {
+	webhook, err := entity.NewWebhook().GetWebhook(s.Webhooks, utils.KeyValue{"_id": id})
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, errs.NewNotFound("webhook %s not found", id.Hex())
+	}
+	if err != nil {
+		return nil, errs.Wrap(err, "failed to get webhook")
+	}
+	return webhook, nil
+}
+
+// checkURL requires an absolute http or https url.
+func checkURL(field string, raw string, violations *validation.Violations) string {
+	if raw == "" {
+		// Reported by the required tag.
+		return raw
+	}
+	parsed, err := url.Parse(raw)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		violations.Add(field, "must be an http or https url, got %q", raw)
+	}
+	return raw
+}
+
+// eventTypes converts the subscribed types, dropping repeats.
+func eventTypes(types []pb2.OrderEventType, violations *validation.Violations) []string {
+	stored := make([]string, 0, len(types))
+	seen := map[string]bool{}
+	for i, wire := range types {
+		eventType, ok := mapper.EventTypeFromProto(wire)
+		if !ok {
+			violations.Add(fmt.Sprintf("webhook.eventTypes[%d]", i), "%s cannot be subscribed to", wire)
+			continue
+		}
+		if !seen[string(eventType)] {
+			seen[string(eventType)] = true
+			stored = append(stored, string(eventType))
+		}
+	}
+	return stored
+}
+
+func pageQuery(size int32, token string) (db.Query, error) {
+	var violations validation.Violations
+	pageSize := int64(size)
+	switch {
+	case pageSize < 0:
+		violations.Add("pageSize", "must not be negative")
+	case pageSize == 0:
+		pageSize = defaultPageSize
+	case pageSize > maxPageSize:
+		pageSize = maxPageSize
+	}
+	return db.Query{Sort: "createdAt", Limit: pageSize, PageToken: token}, violations.Err()
+}
+
+func NewWebhookServer(log *logrus.Logger, repos *repository.Repositories) *WebhookServer {
+	return &WebhookServer{
+		UnimplementedWebhooksServer: pb2.UnimplementedWebhooksServer{},
+		Log:                         log,
+		Webhooks:                    repos.Webhooks,
+		Deliveries:                  repos.Deliveries,
+	}
+}
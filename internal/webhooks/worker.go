@@ -0,0 +1,219 @@
+package webhooks
+
+import (
+	"awesomeProject/internal/entity"
+	"awesomeProject/internal/repository"
+	"awesomeProject/pkg/db"
+	"awesomeProject/pkg/utils"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type Options struct {
+	// Interval is the pause between polls when no delivery is due.
+	Interval time.Duration
+	// Batch is the number of due deliveries attempted at once.
+	Batch int
+	// Timeout bounds each request.
+	Timeout time.Duration
+	// MaxAttempts is the number of attempts before a delivery becomes a
+	// dead letter.
+	MaxAttempts int
+	// Backoff is the pause before the first retry. It doubles with every
+	// further attempt up to MaxBackoff.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+// Worker POSTs the due deliveries. Several workers, in this process or
+// others, can share the deliveries: each one is claimed before the
+// attempt, for long enough that the attempt ends first. A worker that
+// stops mid-attempt leaves the delivery to be retried after the claim.
+type Worker struct {
+	log        *logrus.Logger
+	webhooks   repository.WebhookRepository
+	deliveries repository.WebhookDeliveryRepository
+	client     *http.Client
+	opts       Options
+}
+
+// NewWorker sends the requests with client, or with a default client when
+// it is nil.
+func NewWorker(log *logrus.Logger, repos *repository.Repositories, client *http.Client, opts Options) *Worker {
+	if opts.Interval <= 0 {
+		opts.Interval = time.Second
+	}
+	if opts.Batch <= 0 {
+		opts.Batch = 50
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 10 * time.Second
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 1
+	}
+	if opts.Backoff <= 0 {
+		opts.Backoff = time.Second
+	}
+	if opts.MaxBackoff < opts.Backoff {
+		opts.MaxBackoff = opts.Backoff
+	}
+	if client == nil {
+		client = &http.Client{}
+	}
+	return &Worker{
+		log:        log,
+		webhooks:   repos.Webhooks,
+		deliveries: repos.Deliveries,
+		client:     client,
+		opts:       opts,
+	}
+}
+
+// Run delivers until ctx is done. Full batches are followed by the next
+// one right away.
+func (w *Worker) Run(ctx context.Context) {
+	for {
+		attempted, err := w.deliverDue(ctx)
+		if err != nil && ctx.Err() == nil {
+			w.log.WithError(err).Warning("Failed to load due webhook deliveries")
+		}
+		if err == nil && attempted == w.opts.Batch {
+			continue
+		}
+		select {
+		case <-time.After(w.opts.Interval):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// deliverDue attempts a batch of due deliveries in parallel, so that a
+// slow endpoint holds up the others for one timeout at most.
+func (w *Worker) deliverDue(ctx context.Context) (int, error) {
+	now := time.Now()
+	filter := utils.KeyValue{"status": entity.DeliveryPending, "nextAttemptAt": bson.M{"$lte": now}}
+	due, _, err := w.deliveries.Find(filter, db.Query{Sort: "nextAttemptAt", Limit: int64(w.opts.Batch)})
+	if err != nil {
+		return 0, err
+	}
+	var wg sync.WaitGroup
+	for _, delivery := range due {
+		var claimed bool
+		claimed, err = w.deliveries.Claim(delivery.ID, delivery.NextAttemptAt, now.Add(2*w.opts.Timeout))
+		if err != nil {
+			// The deliveries started so far still finish within this tick.
+			break
+		}
+		if !claimed {
+			continue
+		}
+		wg.Add(1)
+		go func(delivery *entity.WebhookDelivery) {
+			defer wg.Done()
+			w.deliver(ctx, delivery)
+		}(delivery)
+	}
+	wg.Wait()
+	return len(due), err
+}
+
+func (w *Worker) deliver(ctx context.Context, delivery *entity.WebhookDelivery) {
+	log := w.log.WithFields(logrus.Fields{
+		"delivery": delivery.ID.Hex(),
+		"webhook":  delivery.WebhookID.Hex(),
+		"event":    delivery.EventID,
+	})
+	webhook := entity.NewWebhook()
+	_, err := webhook.GetWebhook(w.webhooks, utils.KeyValue{"_id": delivery.WebhookID})
+	switch {
+	case errors.Is(err, db.ErrNotFound):
+		delivery.Abandon("webhook was deleted")
+	case err != nil:
+		log.WithError(err).Warning("Failed to load webhook")
+		return
+	case webhook.Disabled:
+		delivery.Abandon("webhook is disabled")
+	default:
+		code, err := w.post(ctx, webhook, delivery)
+		if ctx.Err() != nil {
+			// Shutting down: the claim runs out and the attempt is
+			// repeated, uncounted.
+			return
+		}
+		if err != nil {
+			delivery.Failed(err.Error(), code, w.retryAt(delivery.Attempts+1))
+		} else {
+			delivery.Delivered(code)
+		}
+	}
+
+	if err := w.deliveries.Replace(delivery); err != nil {
+		log.WithError(err).Warning("Failed to save webhook delivery")
+		return
+	}
+	switch delivery.Status {
+	case entity.DeliveryDelivered:
+		log.Infof("Delivered webhook event in %d attempts", delivery.Attempts)
+	case entity.DeliveryDead:
+		log.Warningf("Webhook delivery is a dead letter after %d attempts: %s", delivery.Attempts, delivery.LastError)
+	default:
+		log.Infof("Webhook delivery failed, retrying at %s: %s", delivery.NextAttemptAt.Format(time.RFC3339), delivery.LastError)
+	}
+}
+
+// post sends one attempt and returns the response status, 0 when there was
+// no response. Only 2xx statuses count as delivered.
+func (w *Worker) post(ctx context.Context, webhook *entity.Webhook, delivery *entity.WebhookDelivery) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, w.opts.Timeout)
+	defer cancel()
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventIDHeader, delivery.EventID)
+	req.Header.Set(EventTypeHeader, delivery.EventType)
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(SignatureHeader, Sign(webhook.Secret, timestamp, body))
+
+	res, err := w.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	// Draining a little of the body lets the connection be reused.
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 64<<10))
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return res.StatusCode, fmt.Errorf("endpoint answered %s", res.Status)
+	}
+	return res.StatusCode, nil
+}
+
+// retryAt is when a delivery that failed its attempts-th attempt is tried
+// again, or the zero time when it is out of attempts.
+func (w *Worker) retryAt(attempts int) time.Time {
+	if attempts >= w.opts.MaxAttempts {
+		return time.Time{}
+	}
+	backoff := w.opts.Backoff
+	for i := 1; i < attempts && backoff < w.opts.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > w.opts.MaxBackoff {
+		backoff = w.opts.MaxBackoff
+	}
+	return time.Now().Add(backoff)
+}
@@ -0,0 +1,424 @@
+package webhooks
+
+import (
+	"awesomeProject/internal/entity"
+	"awesomeProject/internal/errs"
+	"awesomeProject/internal/events"
+	pb2 "awesomeProject/internal/orders/pb"
+	"awesomeProject/internal/outbox"
+	"awesomeProject/internal/repository"
+	"awesomeProject/pkg/db"
+	"awesomeProject/pkg/utils"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// receiver is a local webhook endpoint answering with a settable status.
+type receiver struct {
+	*httptest.Server
+	status int32
+
+	mu       sync.Mutex
+	requests []received
+}
+
+type received struct {
+	header http.Header
+	body   []byte
+}
+
+func newReceiver(t *testing.T) *receiver {
+	r := &receiver{status: http.StatusOK}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		r.mu.Lock()
+		r.requests = append(r.requests, received{header: req.Header.Clone(), body: body})
+		r.mu.Unlock()
+		w.WriteHeader(int(atomic.LoadInt32(&r.status)))
+	}))
+	t.Cleanup(r.Close)
+	return r
+}
+
+func (r *receiver) answer(status int) {
+	atomic.StoreInt32(&r.status, int32(status))
+}
+
+func (r *receiver) received() []received {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]received(nil), r.requests...)
+}
+
+func quietLogger() *logrus.Logger {
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	return log
+}
+
+func newRepositories(t *testing.T) *repository.Repositories {
+	store := db.NewMemoryStore()
+	if err := repository.EnsureMongoIndexes(store); err != nil {
+		t.Fatal(err)
+	}
+	return repository.NewMongoRepositories(store)
+}
+
+func createWebhook(t *testing.T, repos *repository.Repositories, url string) *entity.Webhook {
+	secret, err := NewSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	webhook := entity.NewWebhook()
+	webhook.ID = primitive.NewObjectID()
+	webhook.URL = url
+	webhook.Secret = secret
+	if _, err := webhook.Persist(repos.Webhooks); err != nil {
+		t.Fatal(err)
+	}
+	return webhook
+}
+
+// publishOrderEvent queues the deliveries of an order creation, as the
+// outbox relay would.
+func publishOrderEvent(t *testing.T, repos *repository.Repositories) *entity.OutboxRecord {
+	order := &entity.Order{ID: primitive.NewObjectID(), Status: entity.Processing, OrderNo: "ORD-2026-000001"}
+	record, err := outbox.OrderRecord(events.Created, order, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := NewDispatcher(quietLogger(), repos).Publish(context.Background(), record); err != nil {
+		t.Fatal(err)
+	}
+	return record
+}
+
+func onlyDelivery(t *testing.T, repos *repository.Repositories) *entity.WebhookDelivery {
+	deliveries, _, err := repos.Deliveries.Find(utils.KeyValue{}, db.Query{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deliveries) != 1 {
+		t.Fatalf("got %d deliveries, want 1", len(deliveries))
+	}
+	return deliveries[0]
+}
+
+func TestWorkerDeliversSignedEvents(t *testing.T) {
+	repos := newRepositories(t)
+	endpoint := newReceiver(t)
+	webhook := createWebhook(t, repos, endpoint.URL)
+	record := publishOrderEvent(t, repos)
+	// Publishing a record again must not queue it twice.
+	if err := NewDispatcher(quietLogger(), repos).Publish(context.Background(), record); err != nil {
+		t.Fatal(err)
+	}
+
+	worker := NewWorker(quietLogger(), repos, endpoint.Client(), Options{MaxAttempts: 3})
+	if _, err := worker.deliverDue(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	requests := endpoint.received()
+	if len(requests) != 1 {
+		t.Fatalf("receiver got %d requests, want 1", len(requests))
+	}
+	req := requests[0]
+	if err := Verify(webhook.Secret, req.header, req.body, time.Minute, time.Now()); err != nil {
+		t.Errorf("Verify: %v", err)
+	}
+	if got := req.header.Get(EventIDHeader); got != record.ID.Hex() {
+		t.Errorf("%s = %q, want %q", EventIDHeader, got, record.ID.Hex())
+	}
+	if got := req.header.Get(EventTypeHeader); got != string(events.Created) {
+		t.Errorf("%s = %q, want %q", EventTypeHeader, got, events.Created)
+	}
+	var payload Payload
+	if err := json.Unmarshal(req.body, &payload); err != nil {
+		t.Fatalf("undecodable payload: %v", err)
+	}
+	if payload.ID != record.ID.Hex() || payload.Type != events.Created {
+		t.Errorf("payload = %+v, want event %s of type %s", payload, record.ID.Hex(), events.Created)
+	}
+
+	delivery := onlyDelivery(t, repos)
+	if delivery.Status != entity.DeliveryDelivered || delivery.Attempts != 1 || delivery.ResponseCode != http.StatusOK {
+		t.Errorf("delivery is %s after %d attempts with %d, want delivered after 1 with 200", delivery.Status, delivery.Attempts, delivery.ResponseCode)
+	}
+}
+
+func TestVerifyRejectsForgeries(t *testing.T) {
+	body := []byte(`{"id":"1"}`)
+	now := time.Now()
+	header := http.Header{}
+	header.Set(TimestampHeader, "1700000000")
+	header.Set(SignatureHeader, Sign("secret", 1700000000, body))
+	signedAt := time.Unix(1700000000, 0)
+
+	tests := []struct {
+		name   string
+		secret string
+		body   []byte
+		now    time.Time
+		want   error
+	}{
+		{name: "valid", secret: "secret", body: body, now: signedAt.Add(time.Minute)},
+		{name: "wrong secret", secret: "other", body: body, now: signedAt, want: ErrBadSignature},
+		{name: "changed body", secret: "secret", body: []byte(`{"id":"2"}`), now: signedAt, want: ErrBadSignature},
+		{name: "stale", secret: "secret", body: body, now: now, want: ErrStale},
+		{name: "from the future", secret: "secret", body: body, now: signedAt.Add(-10 * time.Minute), want: ErrStale},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify(tt.secret, header, tt.body, 5*time.Minute, tt.now)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Verify = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestWorkerRetriesUntilDeadLetter(t *testing.T) {
+	repos := newRepositories(t)
+	endpoint := newReceiver(t)
+	endpoint.answer(http.StatusServiceUnavailable)
+	createWebhook(t, repos, endpoint.URL)
+	publishOrderEvent(t, repos)
+
+	worker := NewWorker(quietLogger(), repos, endpoint.Client(), Options{
+		MaxAttempts: 3,
+		Backoff:     time.Millisecond,
+		MaxBackoff:  2 * time.Millisecond,
+	})
+	for attempt := 1; attempt <= 3; attempt++ {
+		time.Sleep(5 * time.Millisecond)
+		if _, err := worker.deliverDue(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		delivery := onlyDelivery(t, repos)
+		if delivery.Attempts != attempt || delivery.ResponseCode != http.StatusServiceUnavailable {
+			t.Fatalf("after attempt %d the delivery has %d attempts and code %d", attempt, delivery.Attempts, delivery.ResponseCode)
+		}
+		want := entity.DeliveryPending
+		if attempt == 3 {
+			want = entity.DeliveryDead
+		}
+		if delivery.Status != want {
+			t.Fatalf("after attempt %d the delivery is %s, want %s", attempt, delivery.Status, want)
+		}
+	}
+
+	// Dead letters are not attempted again.
+	time.Sleep(5 * time.Millisecond)
+	if _, err := worker.deliverDue(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(endpoint.received()); got != 3 {
+		t.Errorf("receiver got %d requests, want 3", got)
+	}
+}
+
+func TestReplayDelivery(t *testing.T) {
+	repos := newRepositories(t)
+	endpoint := newReceiver(t)
+	endpoint.answer(http.StatusInternalServerError)
+	webhook := createWebhook(t, repos, endpoint.URL)
+	record := publishOrderEvent(t, repos)
+	worker := NewWorker(quietLogger(), repos, endpoint.Client(), Options{MaxAttempts: 1})
+	server := NewWebhookServer(quietLogger(), repos)
+	ctx := context.Background()
+
+	pending := onlyDelivery(t, repos)
+	_, err := server.ReplayDelivery(ctx, &pb2.ReplayDeliveryReq{Id: pending.ID.Hex()})
+	if code := status.Code(errs.ToStatus(err)); code != codes.FailedPrecondition {
+		t.Errorf("replaying a pending delivery gave %s, want FailedPrecondition", code)
+	}
+
+	if _, err := worker.deliverDue(ctx); err != nil {
+		t.Fatal(err)
+	}
+	dead := onlyDelivery(t, repos)
+	if dead.Status != entity.DeliveryDead {
+		t.Fatalf("delivery is %s, want dead", dead.Status)
+	}
+
+	endpoint.answer(http.StatusNoContent)
+	res, err := server.ReplayDelivery(ctx, &pb2.ReplayDeliveryReq{Id: dead.ID.Hex()})
+	if err != nil {
+		t.Fatalf("ReplayDelivery: %v", err)
+	}
+	if res.GetDelivery().GetStatus() != pb2.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_PENDING {
+		t.Errorf("replayed delivery is %s, want pending", res.GetDelivery().GetStatus())
+	}
+	if _, err := worker.deliverDue(ctx); err != nil {
+		t.Fatal(err)
+	}
+	delivered := onlyDelivery(t, repos)
+	if delivered.Status != entity.DeliveryDelivered || delivered.Attempts != 1 {
+		t.Errorf("replayed delivery is %s after %d attempts, want delivered after 1", delivered.Status, delivered.Attempts)
+	}
+	requests := endpoint.received()
+	if got := requests[len(requests)-1].header.Get(EventIDHeader); got != record.ID.Hex() {
+		t.Errorf("replay sent event id %q, want the original %q", got, record.ID.Hex())
+	}
+
+	webhook.Disabled = true
+	if _, err := webhook.Persist(repos.Webhooks); err != nil {
+		t.Fatal(err)
+	}
+	_, err = server.ReplayDelivery(ctx, &pb2.ReplayDeliveryReq{Id: delivered.ID.Hex()})
+	if code := status.Code(errs.ToStatus(err)); code != codes.FailedPrecondition {
+		t.Errorf("replaying to a disabled webhook gave %s, want FailedPrecondition", code)
+	}
+}
+
+func TestWorkerAbandonsDisabledWebhooks(t *testing.T) {
+	repos := newRepositories(t)
+	endpoint := newReceiver(t)
+	webhook := createWebhook(t, repos, endpoint.URL)
+	publishOrderEvent(t, repos)
+	webhook.Disabled = true
+	if _, err := webhook.Persist(repos.Webhooks); err != nil {
+		t.Fatal(err)
+	}
+
+	worker := NewWorker(quietLogger(), repos, endpoint.Client(), Options{MaxAttempts: 3})
+	if _, err := worker.deliverDue(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	delivery := onlyDelivery(t, repos)
+	if delivery.Status != entity.DeliveryDead || delivery.Attempts != 0 {
+		t.Errorf("delivery is %s after %d attempts, want dead after 0", delivery.Status, delivery.Attempts)
+	}
+	if got := len(endpoint.received()); got != 0 {
+		t.Errorf("receiver got %d requests, want none", got)
+	}
+}
+
+func TestClaimIsExclusive(t *testing.T) {
+	repos := newRepositories(t)
+	endpoint := newReceiver(t)
+	createWebhook(t, repos, endpoint.URL)
+	publishOrderEvent(t, repos)
+	delivery := onlyDelivery(t, repos)
+
+	until := time.Now().Add(time.Minute).UTC().Truncate(time.Millisecond)
+	first, err := repos.Deliveries.Claim(delivery.ID, delivery.NextAttemptAt, until)
+	if err != nil || !first {
+		t.Fatalf("first claim = %v, %v; want true", first, err)
+	}
+	second, err := repos.Deliveries.Claim(delivery.ID, delivery.NextAttemptAt, until)
+	if err != nil || second {
+		t.Fatalf("second claim = %v, %v; want false", second, err)
+	}
+}
+
+func TestWorkersShareDeliveries(t *testing.T) {
+	repos := newRepositories(t)
+	endpoint := newReceiver(t)
+	createWebhook(t, repos, endpoint.URL)
+	for i := 0; i < 10; i++ {
+		publishOrderEvent(t, repos)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		worker := NewWorker(quietLogger(), repos, endpoint.Client(), Options{MaxAttempts: 3})
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := worker.deliverDue(context.Background()); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := len(endpoint.received()); got != 10 {
+		t.Errorf("receiver got %d requests for 10 deliveries", got)
+	}
+}
+
+// failingClaims fails every claim after the first.
+type failingClaims struct {
+	repository.WebhookDeliveryRepository
+	claims int32
+}
+
+var errClaim = errors.New("claim failed")
+
+func (f *failingClaims) Claim(id primitive.ObjectID, due time.Time, until time.Time) (bool, error) {
+	if atomic.AddInt32(&f.claims, 1) > 1 {
+		return false, errClaim
+	}
+	return f.WebhookDeliveryRepository.Claim(id, due, until)
+}
+
+func TestClaimErrorWaitsForStartedDeliveries(t *testing.T) {
+	repos := newRepositories(t)
+	endpoint := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+	}))
+	t.Cleanup(endpoint.Close)
+	createWebhook(t, repos, endpoint.URL)
+	createWebhook(t, repos, endpoint.URL)
+	publishOrderEvent(t, repos)
+
+	failing := *repos
+	failing.Deliveries = &failingClaims{WebhookDeliveryRepository: repos.Deliveries}
+	worker := NewWorker(quietLogger(), &failing, endpoint.Client(), Options{MaxAttempts: 3})
+	if _, err := worker.deliverDue(context.Background()); !errors.Is(err, errClaim) {
+		t.Fatalf("deliverDue = %v, want the claim error", err)
+	}
+
+	delivered, _, err := repos.Deliveries.Find(utils.KeyValue{"status": entity.DeliveryDelivered}, db.Query{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(delivered) != 1 {
+		t.Errorf("%d deliveries finished before deliverDue returned, want the 1 claimed", len(delivered))
+	}
+}
+
+func TestRetryAt(t *testing.T) {
+	worker := NewWorker(quietLogger(), &repository.Repositories{}, nil, Options{
+		MaxAttempts: 6,
+		Backoff:     time.Second,
+		MaxBackoff:  10 * time.Second,
+	})
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: time.Second},
+		{attempts: 2, want: 2 * time.Second},
+		{attempts: 3, want: 4 * time.Second},
+		{attempts: 4, want: 8 * time.Second},
+		{attempts: 5, want: 10 * time.Second},
+	}
+	for _, tt := range tests {
+		before := time.Now()
+		got := worker.retryAt(tt.attempts)
+		after := time.Now()
+		if got.Before(before.Add(tt.want)) || got.After(after.Add(tt.want)) {
+			t.Errorf("retryAt(%d) is %s from now, want %s", tt.attempts, got.Sub(before), tt.want)
+		}
+	}
+	for _, attempts := range []int{6, 7} {
+		if got := worker.retryAt(attempts); !got.IsZero() {
+			t.Errorf("retryAt(%d) = %s, want the zero time for a dead letter", attempts, got)
+		}
+	}
+}
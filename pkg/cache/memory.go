@@ -0,0 +1,287 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// MemoryCache implements ICache in process for tests and local development.
+// Values are stored exactly as RedisCache would store them.
+type MemoryCache struct {
+	mu      sync.Mutex
+	values  map[string]memoryEntry
+	lists   map[string][]string
+	streams map[string]*memoryStream
+	nowFunc func() time.Time
+}
+
+type memoryStream struct {
+	entries []StreamMessage
+	last    streamID
+	// appended is closed and replaced whenever an entry is added.
+	appended chan struct{}
+}
+
+type memoryEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+func (m *MemoryCache) Set(key string, data interface{}, expiration time.Duration) error {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry := memoryEntry{value: b}
+	if expiration > 0 {
+		entry.expiresAt = m.nowFunc().Add(expiration)
+	}
+	m.values[key] = entry
+	return nil
+}
+
+func (m *MemoryCache) SetNX(key string, data interface{}, expiration time.Duration) (bool, error) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return false, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.live(key); ok {
+		return false, nil
+	}
+	entry := memoryEntry{value: b}
+	if expiration > 0 {
+		entry.expiresAt = m.nowFunc().Add(expiration)
+	}
+	m.values[key] = entry
+	return true, nil
+}
+
+func (m *MemoryCache) Get(key string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry, ok := m.live(key)
+	if !ok {
+		return nil, nil
+	}
+	return entry.value, nil
+}
+
+func (m *MemoryCache) Delete(key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.values, key)
+	delete(m.lists, key)
+	return nil
+}
+
+func (m *MemoryCache) Incr(key string) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var n int64
+	entry, ok := m.live(key)
+	if ok {
+		var err error
+		n, err = strconv.ParseInt(string(entry.value), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("cache: %s is not an integer", key)
+		}
+	}
+	n++
+	entry.value = []byte(strconv.FormatInt(n, 10))
+	m.values[key] = entry
+	return n, nil
+}
+
+func (m *MemoryCache) LPush(key string, data ...interface{}) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, item := range data {
+		var value string
+		switch v := item.(type) {
+		case string:
+			value = v
+		case []byte:
+			value = string(v)
+		default:
+			value = fmt.Sprint(v)
+		}
+		m.lists[key] = append([]string{value}, m.lists[key]...)
+	}
+	return nil
+}
+
+func (m *MemoryCache) LRange(key string, start int64, end int64) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	list := m.lists[key]
+	n := int64(len(list))
+	if start < 0 {
+		start += n
+	}
+	if end < 0 {
+		end += n
+	}
+	if start < 0 {
+		start = 0
+	}
+	if end >= n {
+		end = n - 1
+	}
+	if start > end {
+		return []string{}, nil
+	}
+	return append([]string{}, list[start:end+1]...), nil
+}
+
+func (m *MemoryCache) Scan(key string) []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var values []string
+	for k := range m.values {
+		if _, ok := m.live(k); !ok {
+			continue
+		}
+		if matched, _ := path.Match(key, k); matched {
+			values = append(values, k)
+		}
+	}
+	for k := range m.lists {
+		if matched, _ := path.Match(key, k); matched {
+			values = append(values, k)
+		}
+	}
+	return values
+}
+
+func (m *MemoryCache) XAdd(stream string, maxLen int64, values map[string]string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s := m.stream(stream)
+	id := streamID{ms: m.nowFunc().UnixMilli()}
+	if !id.after(s.last) {
+		id = streamID{ms: s.last.ms, seq: s.last.seq + 1}
+	}
+	copied := make(map[string]string, len(values))
+	for key, value := range values {
+		copied[key] = value
+	}
+	s.entries = append(s.entries, StreamMessage{ID: id.String(), Values: copied})
+	if maxLen > 0 && int64(len(s.entries)) > maxLen {
+		s.entries = s.entries[int64(len(s.entries))-maxLen:]
+	}
+	s.last = id
+	close(s.appended)
+	s.appended = make(chan struct{})
+	return id.String(), nil
+}
+
+func (m *MemoryCache) XRead(ctx context.Context, stream string, after string, count int64, block time.Duration) ([]StreamMessage, error) {
+	from, err := parseStreamID(after)
+	if err != nil {
+		return nil, err
+	}
+	timer := time.NewTimer(block)
+	defer timer.Stop()
+	for {
+		m.mu.Lock()
+		s := m.stream(stream)
+		var messages []StreamMessage
+		for _, entry := range s.entries {
+			id, _ := parseStreamID(entry.ID)
+			if id.after(from) && (count <= 0 || int64(len(messages)) < count) {
+				messages = append(messages, entry)
+			}
+		}
+		appended := s.appended
+		m.mu.Unlock()
+		if len(messages) > 0 {
+			return messages, nil
+		}
+
+		select {
+		case <-appended:
+		case <-timer.C:
+			return nil, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (m *MemoryCache) XRevRange(stream string, end string, start string, count int64) ([]StreamMessage, error) {
+	to, err := parseStreamID(end)
+	if err != nil {
+		return nil, err
+	}
+	from, err := parseStreamID(start)
+	if err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entries := m.stream(stream).entries
+	messages := []StreamMessage{}
+	for i := len(entries) - 1; i >= 0 && (count <= 0 || int64(len(messages)) < count); i-- {
+		id, _ := parseStreamID(entries[i].ID)
+		if !id.after(to) && !from.after(id) {
+			messages = append(messages, entries[i])
+		}
+	}
+	return messages, nil
+}
+
+func (m *MemoryCache) Lock(key string, owner string, ttl time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry, ok := m.live(key)
+	if ok && string(entry.value) != owner {
+		return false, nil
+	}
+	m.values[key] = memoryEntry{value: []byte(owner), expiresAt: m.nowFunc().Add(ttl)}
+	return true, nil
+}
+
+// stream returns the stream at key, creating it when missing. Callers must
+// hold the lock.
+func (m *MemoryCache) stream(key string) *memoryStream {
+	s, ok := m.streams[key]
+	if !ok {
+		s = &memoryStream{appended: make(chan struct{})}
+		m.streams[key] = s
+	}
+	return s
+}
+
+// live returns an entry that has not expired, evicting it otherwise.
+// Callers must hold the lock.
+func (m *MemoryCache) live(key string) (memoryEntry, bool) {
+	entry, ok := m.values[key]
+	if !ok {
+		return entry, false
+	}
+	if !entry.expiresAt.IsZero() && !m.nowFunc().Before(entry.expiresAt) {
+		delete(m.values, key)
+		return entry, false
+	}
+	return entry, true
+}
+
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{
+		values:  map[string]memoryEntry{},
+		lists:   map[string][]string{},
+		streams: map[string]*memoryStream{},
+		nowFunc: time.Now,
+	}
+}
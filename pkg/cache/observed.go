@@ -0,0 +1,98 @@
+package cache
+
+import "time"
+
+// The results an Observer is told about. Reads report Hit or Miss instead
+// of OK.
+const (
+	ResultOK    = "ok"
+	ResultHit   = "hit"
+	ResultMiss  = "miss"
+	ResultError = "error"
+)
+
+// Observer is told about every cache operation once it returns.
+type Observer func(operation string, result string, took time.Duration)
+
+// ObservedCache reports the operations of an ICache to an Observer, for
+// metrics. It only implements ICache, so streams must be taken from the
+// cache it wraps.
+type ObservedCache struct {
+	cache   ICache
+	observe Observer
+}
+
+func NewObservedCache(cache ICache, observe Observer) *ObservedCache {
+	return &ObservedCache{cache: cache, observe: observe}
+}
+
+func (c *ObservedCache) done(operation string, start time.Time, err error) {
+	result := ResultOK
+	if err != nil {
+		result = ResultError
+	}
+	c.observe(operation, result, time.Since(start))
+}
+
+func (c *ObservedCache) Set(key string, data interface{}, expiration time.Duration) error {
+	start := time.Now()
+	err := c.cache.Set(key, data, expiration)
+	c.done("set", start, err)
+	return err
+}
+
+func (c *ObservedCache) Get(key string) ([]byte, error) {
+	start := time.Now()
+	value, err := c.cache.Get(key)
+	result := ResultHit
+	switch {
+	case err != nil:
+		result = ResultError
+	case value == nil:
+		result = ResultMiss
+	}
+	c.observe("get", result, time.Since(start))
+	return value, err
+}
+
+func (c *ObservedCache) LPush(key string, data ...interface{}) error {
+	start := time.Now()
+	err := c.cache.LPush(key, data...)
+	c.done("lpush", start, err)
+	return err
+}
+
+func (c *ObservedCache) LRange(key string, start int64, end int64) ([]string, error) {
+	began := time.Now()
+	values, err := c.cache.LRange(key, start, end)
+	c.done("lrange", began, err)
+	return values, err
+}
+
+func (c *ObservedCache) Scan(key string) []string {
+	start := time.Now()
+	keys := c.cache.Scan(key)
+	c.done("scan", start, nil)
+	return keys
+}
+
+func (c *ObservedCache) Delete(key string) error {
+	start := time.Now()
+	err := c.cache.Delete(key)
+	c.done("delete", start, err)
+	return err
+}
+
+func (c *ObservedCache) Incr(key string) (int64, error) {
+	start := time.Now()
+	n, err := c.cache.Incr(key)
+	c.done("incr", start, err)
+	return n, err
+}
+
+func (c *ObservedCache) SetNX(key string, data interface{}, expiration time.Duration) (bool, error) {
+	start := time.Now()
+	set, err := c.cache.SetNX(key, data, expiration)
+	c.done("setnx", start, err)
+	return set, err
+}
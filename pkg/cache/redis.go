@@ -1,6 +1,7 @@
 package cache
 
 import (
+	"awesomeProject/pkg/config"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -17,6 +18,12 @@ type ICache interface {
 	LPush(key string, data ...interface{}) error
 	LRange(key string, start int64, end int64) ([]string, error)
 	Scan(key string) []string
+	Delete(key string) error
+	// Incr atomically adds one to the integer at key, which starts at 0.
+	Incr(key string) (int64, error)
+	// SetNX is Set for a key that does not exist yet, reporting whether it
+	// was set.
+	SetNX(key string, data interface{}, expiration time.Duration) (bool, error)
 }
 
 type RedisCache struct {
@@ -40,6 +47,22 @@ func (r *RedisCache) Get(key string) ([]byte, error) {
 	return []byte(result), err
 }
 
+func (r *RedisCache) SetNX(key string, data interface{}, expiration time.Duration) (bool, error) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return false, err
+	}
+	return r.client.SetNX(context.Background(), key, b, expiration).Result()
+}
+
+func (r *RedisCache) Delete(key string) error {
+	return r.client.Del(context.Background(), key).Err()
+}
+
+func (r *RedisCache) Incr(key string) (int64, error) {
+	return r.client.Incr(context.Background(), key).Result()
+}
+
 func (r *RedisCache) LPush(key string, data ...interface{}) error {
 	err := r.client.LPush(context.Background(), key, data).Err()
 	if err != nil {
@@ -70,21 +93,90 @@ func (r *RedisCache) Scan(key string) []string {
 	return values
 }
 
-func InitRedisCache() *RedisCache {
+func (r *RedisCache) XAdd(stream string, maxLen int64, values map[string]string) (string, error) {
+	fields := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		fields[key] = value
+	}
+	return r.client.XAdd(context.Background(), &redis.XAddArgs{
+		Stream: stream,
+		MaxLen: maxLen,
+		Approx: true,
+		Values: fields,
+	}).Result()
+}
+
+func (r *RedisCache) XRead(ctx context.Context, stream string, after string, count int64, block time.Duration) ([]StreamMessage, error) {
+	result, err := r.client.XRead(ctx, &redis.XReadArgs{
+		Streams: []string{stream, after},
+		Count:   count,
+		Block:   block,
+	}).Result()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var messages []StreamMessage
+	for _, s := range result {
+		messages = append(messages, streamMessages(s.Messages)...)
+	}
+	return messages, nil
+}
+
+func (r *RedisCache) XRevRange(stream string, end string, start string, count int64) ([]StreamMessage, error) {
+	result, err := r.client.XRevRangeN(context.Background(), stream, end, start, count).Result()
+	if err != nil {
+		return nil, err
+	}
+	return streamMessages(result), nil
+}
+
+func streamMessages(result []redis.XMessage) []StreamMessage {
+	messages := make([]StreamMessage, 0, len(result))
+	for _, m := range result {
+		values := make(map[string]string, len(m.Values))
+		for key, value := range m.Values {
+			values[key] = fmt.Sprint(value)
+		}
+		messages = append(messages, StreamMessage{ID: m.ID, Values: values})
+	}
+	return messages
+}
+
+// lockScript extends the lock when the owner holds it and takes it when
+// nobody does, in one round trip.
+var lockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+if redis.call("SET", KEYS[1], ARGV[1], "NX", "PX", ARGV[2]) then
+	return 1
+end
+return 0
+`)
+
+func (r *RedisCache) Lock(key string, owner string, ttl time.Duration) (bool, error) {
+	held, err := lockScript.Run(context.Background(), r.client, []string{key}, owner, ttl.Milliseconds()).Int()
+	return held == 1, err
+}
+
+func InitRedisCache(cfg config.Redis) (*RedisCache, error) {
 	var red = &RedisCache{
 		client: redis.NewClient(&redis.Options{
-			Addr:     "localhost:6379",
-			Password: "", // no password set
-			DB:       0,  // use default DB
+			Addr:     cfg.Addr,
+			Password: cfg.Password,
+			DB:       cfg.DB,
 		}),
 	}
 
 	err := ping(red.client)
 	if err != nil {
-		return nil
+		return nil, err
 	}
 
-	return red
+	return red, nil
 }
 
 func ping(client *redis.Client) error {
@@ -0,0 +1,68 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StreamMessage is one entry of a stream.
+type StreamMessage struct {
+	ID     string
+	Values map[string]string
+}
+
+// Streams are append-only logs shared by the server instances, with the
+// semantics of Redis streams. Ids are "<milliseconds>-<sequence>"; "-" and
+// "+" stand for the first and last possible id in ranges.
+type Streams interface {
+	// XAdd appends values to stream, trimming it to about maxLen entries,
+	// and returns the id of the new entry.
+	XAdd(stream string, maxLen int64, values map[string]string) (string, error)
+	// XRead waits up to block for entries after the id after and returns
+	// at most count of them, none when the wait timed out.
+	XRead(ctx context.Context, stream string, after string, count int64, block time.Duration) ([]StreamMessage, error)
+	// XRevRange returns at most count entries from end down to start.
+	XRevRange(stream string, end string, start string, count int64) ([]StreamMessage, error)
+	// Lock takes the lock at key for owner, or extends it when owner
+	// already holds it, and reports whether owner holds it for ttl.
+	Lock(key string, owner string, ttl time.Duration) (bool, error)
+}
+
+// streamID is a parsed stream entry id.
+type streamID struct {
+	ms  int64
+	seq int64
+}
+
+func parseStreamID(id string) (streamID, error) {
+	switch id {
+	case "-":
+		return streamID{}, nil
+	case "+":
+		return streamID{ms: math.MaxInt64, seq: math.MaxInt64}, nil
+	}
+	msPart, seqPart, found := strings.Cut(id, "-")
+	ms, err := strconv.ParseInt(msPart, 10, 64)
+	if err != nil || ms < 0 {
+		return streamID{}, fmt.Errorf("cache: invalid stream id %q", id)
+	}
+	var seq int64
+	if found {
+		if seq, err = strconv.ParseInt(seqPart, 10, 64); err != nil || seq < 0 {
+			return streamID{}, fmt.Errorf("cache: invalid stream id %q", id)
+		}
+	}
+	return streamID{ms: ms, seq: seq}, nil
+}
+
+func (id streamID) after(other streamID) bool {
+	return id.ms > other.ms || id.ms == other.ms && id.seq > other.seq
+}
+
+func (id streamID) String() string {
+	return fmt.Sprintf("%d-%d", id.ms, id.seq)
+}
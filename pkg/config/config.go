@@ -0,0 +1,340 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"gopkg.in/yaml.v3"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EnvVar selects the profile loaded from config/<profile>.yml.
+const EnvVar = "APP_ENV"
+
+// DirVar overrides the directory the profiles are read from.
+const DirVar = "CONFIG_DIR"
+
+const defaultEnv = "dev"
+
+var profiles = []string{"dev", "qa", "staging", "prod"}
+
+type Config struct {
+	Env          string       `yaml:"-"`
+	Port         string       `yaml:"port" env:"PORT" validate:"required,numeric"`
+	Store        Store        `yaml:"store"`
+	Mongo        Mongo        `yaml:"mongo"`
+	Redis        Redis        `yaml:"redis"`
+	Pricing      Pricing      `yaml:"pricing"`
+	OrderNumbers OrderNumbers `yaml:"orderNumbers"`
+	Streams      Streams      `yaml:"streams"`
+	Outbox       Outbox       `yaml:"outbox"`
+	Webhooks     Webhooks     `yaml:"webhooks"`
+	Idempotency  Idempotency  `yaml:"idempotency"`
+	GRPC         GRPC         `yaml:"grpc"`
+	Metrics      Metrics      `yaml:"metrics"`
+}
+
+type Store struct {
+	// Driver is one of mongo, memory, postgres or sqlite.
+	Driver string `yaml:"driver" env:"STORE" validate:"required,oneof=mongo memory postgres sqlite"`
+	// DSN is the data source of the postgres and sqlite drivers.
+	DSN string `yaml:"dsn" env:"DATABASE_URL"`
+}
+
+type Mongo struct {
+	URI            string        `yaml:"uri" env:"MONGO_URI"`
+	Database       string        `yaml:"database" env:"MONGO_DATABASE"`
+	ConnectTimeout time.Duration `yaml:"connectTimeout" env:"MONGO_CONNECT_TIMEOUT" validate:"min=0"`
+}
+
+// Pricing sets how order totals are computed. Amounts are in minor units of
+// Currency and TaxRate is in basis points, so 1950 is 19.5%.
+type Pricing struct {
+	Currency         string `yaml:"currency" env:"PRICING_CURRENCY" validate:"required,len=3,alpha,uppercase"`
+	TaxRate          int64  `yaml:"taxRate" env:"PRICING_TAX_RATE" validate:"min=0,max=10000"`
+	ShippingFee      int64  `yaml:"shippingFee" env:"PRICING_SHIPPING_FEE" validate:"min=0"`
+	FreeShippingFrom int64  `yaml:"freeShippingFrom" env:"PRICING_FREE_SHIPPING_FROM" validate:"min=0"`
+}
+
+// OrderNumbers sets how CreateOrder numbers orders. Format takes {year},
+// {month}, {day} and one {seq:N} token; Sequence counts in the store or
+// with Redis INCR.
+type OrderNumbers struct {
+	Format   string `yaml:"format" env:"ORDER_NUMBER_FORMAT" validate:"required"`
+	Sequence string `yaml:"sequence" env:"ORDER_NUMBER_SEQUENCE" validate:"required,oneof=store redis"`
+}
+
+// Streams sets how order changes are fanned out to streaming clients.
+// Buffer is the number of events a client may fall behind by before
+// SlowConsumer applies: drop skips events, disconnect ends the stream.
+// History is the number of recent events a reconnecting client can resume
+// from; older resume tokens get a snapshot of the orders instead.
+//
+// Fanout local has every instance read the changes from the store itself.
+// With redis the changes are relayed once to the Redis stream RedisKey and
+// every instance reads them from there; other services may add order
+// events to that stream too.
+type Streams struct {
+	Buffer       int    `yaml:"buffer" env:"STREAM_BUFFER" validate:"min=1"`
+	SlowConsumer string `yaml:"slowConsumer" env:"STREAM_SLOW_CONSUMER" validate:"required,oneof=drop disconnect"`
+	History      int    `yaml:"history" env:"STREAM_HISTORY" validate:"min=0"`
+	Fanout       string `yaml:"fanout" env:"STREAM_FANOUT" validate:"required,oneof=local redis"`
+	RedisKey     string `yaml:"redisKey" env:"STREAM_REDIS_KEY" validate:"required"`
+}
+
+// Outbox sets how the order changes written to the outbox are published.
+// Publisher log only logs them; redis appends them to the Redis stream
+// RedisKey. The relay polls every Interval for up to Batch messages and
+// keeps published ones for Retention, 0 for ever.
+type Outbox struct {
+	Publisher string        `yaml:"publisher" env:"OUTBOX_PUBLISHER" validate:"required,oneof=log redis"`
+	RedisKey  string        `yaml:"redisKey" env:"OUTBOX_REDIS_KEY" validate:"required"`
+	Interval  time.Duration `yaml:"interval" env:"OUTBOX_INTERVAL" validate:"min=0"`
+	Batch     int           `yaml:"batch" env:"OUTBOX_BATCH" validate:"min=1"`
+	Retention time.Duration `yaml:"retention" env:"OUTBOX_RETENTION" validate:"min=0"`
+}
+
+// Webhooks sets how order events are delivered to webhooks. The worker
+// polls every Interval for up to Batch due deliveries and gives each
+// request Timeout. A failed delivery is retried after Backoff, doubling up
+// to MaxBackoff, and becomes a dead letter after MaxAttempts attempts.
+type Webhooks struct {
+	Interval    time.Duration `yaml:"interval" env:"WEBHOOK_INTERVAL" validate:"min=0"`
+	Batch       int           `yaml:"batch" env:"WEBHOOK_BATCH" validate:"min=1"`
+	Timeout     time.Duration `yaml:"timeout" env:"WEBHOOK_TIMEOUT" validate:"min=0"`
+	MaxAttempts int           `yaml:"maxAttempts" env:"WEBHOOK_MAX_ATTEMPTS" validate:"min=1"`
+	Backoff     time.Duration `yaml:"backoff" env:"WEBHOOK_BACKOFF" validate:"min=0"`
+	MaxBackoff  time.Duration `yaml:"maxBackoff" env:"WEBHOOK_MAX_BACKOFF" validate:"min=0"`
+}
+
+// Idempotency sets how long the responses to CreateOrder and
+// CreateCustomer calls with an idempotency key are kept in Redis. Retries
+// within Window get the stored response.
+type Idempotency struct {
+	Window time.Duration `yaml:"window" env:"IDEMPOTENCY_WINDOW" validate:"min=1s"`
+}
+
+// GRPC sets the deadline of unary calls whose client sent none, 0 for no
+// deadline.
+type GRPC struct {
+	Timeout time.Duration `yaml:"timeout" env:"GRPC_TIMEOUT" validate:"min=0"`
+}
+
+// Metrics sets the port of the HTTP server exposing the Prometheus metrics
+// at /metrics, none when empty.
+type Metrics struct {
+	Port string `yaml:"port" env:"METRICS_PORT" validate:"omitempty,numeric"`
+}
+
+type Redis struct {
+	Addr     string `yaml:"addr" env:"REDIS_ADDR"`
+	Password string `yaml:"password" env:"REDIS_PASSWORD"`
+	DB       int    `yaml:"db" env:"REDIS_DB" validate:"min=0"`
+}
+
+// Load reads the profile named by APP_ENV (dev when unset), overlays the
+// environment variables named in the env tags and validates the result.
+func Load() (*Config, error) {
+	env := os.Getenv(EnvVar)
+	if env == "" {
+		env = defaultEnv
+	}
+	dir := os.Getenv(DirVar)
+	if dir == "" {
+		dir = "config"
+	}
+	return LoadFile(env, dir, os.LookupEnv)
+}
+
+// LoadFile loads a profile from dir, taking overrides from lookup.
+func LoadFile(env string, dir string, lookup func(string) (string, bool)) (*Config, error) {
+	if !validProfile(env) {
+		return nil, fmt.Errorf("config: unknown %s %q, expected one of %s", EnvVar, env, strings.Join(profiles, ", "))
+	}
+
+	cfg := defaults()
+	cfg.Env = env
+	path := filepath.Join(dir, env+".yml")
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("config: reading %s: %w", path, err)
+	}
+	if err := yaml.Unmarshal(b, cfg); err != nil {
+		return nil, fmt.Errorf("config: parsing %s: %w", path, err)
+	}
+	if err := overlay(reflect.ValueOf(cfg).Elem(), lookup); err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("config: %s profile is invalid: %w", env, err)
+	}
+	return cfg, nil
+}
+
+// Validate checks the required fields, including the ones only the selected
+// store driver needs.
+func (c *Config) Validate() error {
+	var problems []string
+	err := validator.New().Struct(c)
+	var invalid validator.ValidationErrors
+	if errors.As(err, &invalid) {
+		for _, fieldErr := range invalid {
+			problems = append(problems, describe(fieldErr))
+		}
+	} else if err != nil {
+		return err
+	}
+
+	switch c.Store.Driver {
+	case "mongo":
+		if c.Mongo.URI == "" {
+			problems = append(problems, "mongo.uri is required (MONGO_URI)")
+		}
+		if c.Mongo.Database == "" {
+			problems = append(problems, "mongo.database is required (MONGO_DATABASE)")
+		}
+	case "postgres", "sqlite":
+		if c.Store.DSN == "" {
+			problems = append(problems, "store.dsn is required for the "+c.Store.Driver+" driver (DATABASE_URL)")
+		}
+	}
+	if c.Store.Driver != "memory" && c.Redis.Addr == "" {
+		problems = append(problems, "redis.addr is required (REDIS_ADDR)")
+	}
+
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func defaults() *Config {
+	return &Config{
+		Port:    "7100",
+		Store:   Store{Driver: "mongo"},
+		Mongo:   Mongo{ConnectTimeout: 10 * time.Second},
+		Pricing: Pricing{Currency: "EUR"},
+		OrderNumbers: OrderNumbers{
+			Format:   "ORD-{year}-{seq:6}",
+			Sequence: "store",
+		},
+		Streams: Streams{
+			Buffer:       256,
+			SlowConsumer: "disconnect",
+			History:      1000,
+			Fanout:       "local",
+			RedisKey:     "order-events",
+		},
+		Outbox: Outbox{
+			Publisher: "log",
+			RedisKey:  "order-outbox",
+			Interval:  time.Second,
+			Batch:     100,
+			Retention: 24 * time.Hour,
+		},
+		Webhooks: Webhooks{
+			Interval:    time.Second,
+			Batch:       50,
+			Timeout:     10 * time.Second,
+			MaxAttempts: 10,
+			Backoff:     30 * time.Second,
+			MaxBackoff:  time.Hour,
+		},
+		Idempotency: Idempotency{Window: 24 * time.Hour},
+		GRPC:        GRPC{Timeout: 30 * time.Second},
+		Metrics:     Metrics{Port: "9100"},
+	}
+}
+
+func validProfile(env string) bool {
+	for _, profile := range profiles {
+		if profile == env {
+			return true
+		}
+	}
+	return false
+}
+
+// overlay replaces fields tagged with env by the variables that are set.
+func overlay(v reflect.Value, lookup func(string) (string, bool)) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := v.Field(i)
+		if field.Kind() == reflect.Struct {
+			if err := overlay(field, lookup); err != nil {
+				return err
+			}
+			continue
+		}
+		name := t.Field(i).Tag.Get("env")
+		if name == "" {
+			continue
+		}
+		value, ok := lookup(name)
+		if !ok {
+			continue
+		}
+		if err := setField(field, value); err != nil {
+			return fmt.Errorf("config: invalid %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func setField(field reflect.Value, value string) error {
+	if field.Type() == reflect.TypeOf(time.Duration(0)) {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(d))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+	return nil
+}
+
+// describe turns a validator error into "store.driver must be one of ...".
+func describe(fieldErr validator.FieldError) string {
+	path := strings.SplitN(fieldErr.Namespace(), ".", 2)
+	name := path[len(path)-1]
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		parts[i] = strings.ToLower(part[:1]) + part[1:]
+	}
+	name = strings.Join(parts, ".")
+
+	switch fieldErr.Tag() {
+	case "required":
+		return name + " is required"
+	case "numeric":
+		return fmt.Sprintf("%s must be numeric, got %q", name, fieldErr.Value())
+	case "oneof":
+		return fmt.Sprintf("%s must be one of %s, got %q", name, fieldErr.Param(), fieldErr.Value())
+	default:
+		return fmt.Sprintf("%s failed %s=%s, got %v", name, fieldErr.Tag(), fieldErr.Param(), fieldErr.Value())
+	}
+}
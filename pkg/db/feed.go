@@ -0,0 +1,224 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"sync"
+	"time"
+)
+
+// ChangeFeed emits Mongo shaped change events for stores that have no
+// change streams of their own.
+type ChangeFeed struct {
+	mu       sync.Mutex
+	seq      int64
+	watchers map[string][]*feedStream
+	// target is set on held feeds, which keep their changes in pending
+	// until Release.
+	target  *ChangeFeed
+	pending []heldChange
+}
+
+type heldChange struct {
+	collectionName string
+	operationType  string
+	id             interface{}
+	updatedFields  bson.M
+	fullDocument   interface{}
+}
+
+func NewChangeFeed() *ChangeFeed {
+	return &ChangeFeed{watchers: map[string][]*feedStream{}}
+}
+
+// Publish records a change on a collection. fullDocument is nil for deletes.
+func (f *ChangeFeed) Publish(collectionName string, operationType string, id interface{}, fullDocument interface{}) error {
+	return f.publish(collectionName, operationType, id, nil, fullDocument)
+}
+
+// PublishUpdate records an update, listing the top level fields it set like
+// the updateDescription of a Mongo change event.
+func (f *ChangeFeed) PublishUpdate(collectionName string, id interface{}, updatedFields bson.M, fullDocument interface{}) error {
+	return f.publish(collectionName, "update", id, updatedFields, fullDocument)
+}
+
+// Hold returns a feed that keeps the changes published to it until Release
+// passes them on to f, for writes that only count once a transaction
+// commits.
+func (f *ChangeFeed) Hold() *ChangeFeed {
+	return &ChangeFeed{watchers: map[string][]*feedStream{}, target: f}
+}
+
+// Release publishes the changes a held feed kept, in order.
+func (f *ChangeFeed) Release() error {
+	f.mu.Lock()
+	pending := f.pending
+	f.pending = nil
+	f.mu.Unlock()
+	for _, c := range pending {
+		if err := f.target.publish(c.collectionName, c.operationType, c.id, c.updatedFields, c.fullDocument); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *ChangeFeed) publish(collectionName string, operationType string, id interface{}, updatedFields bson.M, fullDocument interface{}) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.target != nil {
+		f.pending = append(f.pending, heldChange{collectionName, operationType, id, updatedFields, fullDocument})
+		return nil
+	}
+
+	f.seq++
+	event := bson.M{
+		"_id":           bson.M{"_data": fmt.Sprintf("%016x", f.seq)},
+		"operationType": operationType,
+		"clusterTime":   primitive.Timestamp{T: uint32(time.Now().Unix())},
+		"ns":            bson.M{"coll": collectionName},
+		"documentKey":   bson.M{"_id": id},
+	}
+	if fullDocument != nil {
+		event["fullDocument"] = fullDocument
+	}
+	if updatedFields != nil {
+		event["updateDescription"] = bson.M{"updatedFields": updatedFields, "removedFields": bson.A{}}
+	}
+	raw, err := bson.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	for _, w := range f.watchers[collectionName] {
+		if w.accepts(operationType) {
+			w.push(raw)
+		}
+	}
+	return nil
+}
+
+// Watch opens a stream of the changes made to a collection. With no
+// operation types it mirrors MongoStore.Watch: inserts, updates, replaces
+// and deletes.
+func (f *ChangeFeed) Watch(collectionName string, operationTypes ...string) ChangeStream {
+	if len(operationTypes) == 0 {
+		operationTypes = []string{"insert", "update", "replace", "delete"}
+	}
+	w := &feedStream{
+		feed:       f,
+		collection: collectionName,
+		operations: operationTypes,
+		notify:     make(chan struct{}, 1),
+	}
+
+	f.mu.Lock()
+	f.watchers[collectionName] = append(f.watchers[collectionName], w)
+	f.mu.Unlock()
+	return w
+}
+
+func (f *ChangeFeed) remove(w *feedStream) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	watchers := f.watchers[w.collection]
+	for i, candidate := range watchers {
+		if candidate == w {
+			f.watchers[w.collection] = append(watchers[:i], watchers[i+1:]...)
+			return
+		}
+	}
+}
+
+// feedStream is an unbounded queue so that, like a change stream, a slow
+// reader never loses events.
+type feedStream struct {
+	feed       *ChangeFeed
+	collection string
+	operations []string
+
+	mu      sync.Mutex
+	queue   []bson.Raw
+	current bson.Raw
+	closed  bool
+	err     error
+	notify  chan struct{}
+}
+
+func (w *feedStream) accepts(operationType string) bool {
+	for _, op := range w.operations {
+		if op == operationType {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *feedStream) push(raw bson.Raw) {
+	w.mu.Lock()
+	w.queue = append(w.queue, raw)
+	w.mu.Unlock()
+
+	select {
+	case w.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (w *feedStream) Next(ctx context.Context) bool {
+	for {
+		w.mu.Lock()
+		if w.closed {
+			w.mu.Unlock()
+			return false
+		}
+		if len(w.queue) > 0 {
+			w.current = w.queue[0]
+			w.queue = w.queue[1:]
+			w.mu.Unlock()
+			return true
+		}
+		w.mu.Unlock()
+
+		select {
+		case <-w.notify:
+		case <-ctx.Done():
+			w.mu.Lock()
+			w.err = ctx.Err()
+			w.mu.Unlock()
+			return false
+		}
+	}
+}
+
+func (w *feedStream) Decode(val interface{}) error {
+	w.mu.Lock()
+	current := w.current
+	w.mu.Unlock()
+	return bson.Unmarshal(current, val)
+}
+
+func (w *feedStream) Err() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.err
+}
+
+func (w *feedStream) Close(ctx context.Context) error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return nil
+	}
+	w.closed = true
+	w.mu.Unlock()
+
+	w.feed.remove(w)
+	select {
+	case w.notify <- struct{}{}:
+	default:
+	}
+	return nil
+}
@@ -0,0 +1,355 @@
+package db
+
+import (
+	"bytes"
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"reflect"
+	"strings"
+)
+
+// toDocument normalises any bson marshallable value into a bson.M whose
+// values use the same Go types a decoded document would.
+func toDocument(v interface{}) (bson.M, error) {
+	if v == nil {
+		return bson.M{}, nil
+	}
+	raw, err := bson.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	doc := bson.M{}
+	err = bson.Unmarshal(raw, &doc)
+	return doc, err
+}
+
+// decodeInto copies a stored document into a caller supplied value.
+func decodeInto(doc bson.M, document interface{}) error {
+	raw, err := bson.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	return bson.Unmarshal(raw, document)
+}
+
+func asDocument(v interface{}) (bson.M, bool) {
+	switch d := v.(type) {
+	case bson.M:
+		return d, true
+	case primitive.D:
+		return d.Map(), true
+	}
+	return nil, false
+}
+
+func asArray(v interface{}) (primitive.A, bool) {
+	a, ok := v.(primitive.A)
+	return a, ok
+}
+
+// lookup returns every value found at a dotted path, descending into arrays
+// of sub documents the way Mongo queries do.
+func lookup(v interface{}, path []string) []interface{} {
+	if len(path) == 0 {
+		if arr, ok := asArray(v); ok {
+			return append([]interface{}{v}, arr...)
+		}
+		return []interface{}{v}
+	}
+	if doc, ok := asDocument(v); ok {
+		next, found := doc[path[0]]
+		if !found {
+			return nil
+		}
+		return lookup(next, path[1:])
+	}
+	if arr, ok := asArray(v); ok {
+		var values []interface{}
+		for _, item := range arr {
+			values = append(values, lookup(item, path)...)
+		}
+		return values
+	}
+	return nil
+}
+
+// matches reports whether doc satisfies a Mongo style filter. Supported are
+// equality on (nested) fields, $and/$or and the comparison operators.
+func matches(doc bson.M, filter bson.M) bool {
+	for key, cond := range filter {
+		switch key {
+		case "$and", "$or":
+			clauses, _ := asArray(cond)
+			matched := false
+			for _, clause := range clauses {
+				sub, _ := asDocument(clause)
+				ok := matches(doc, sub)
+				if key == "$and" && !ok {
+					return false
+				}
+				matched = matched || ok
+			}
+			if key == "$or" && !matched {
+				return false
+			}
+			continue
+		}
+
+		values := lookup(doc, strings.Split(key, "."))
+		if ops, ok := operators(cond); ok {
+			for op, operand := range ops {
+				if !matchOperator(values, op, operand) {
+					return false
+				}
+			}
+			continue
+		}
+		if !matchOperator(values, "$eq", cond) {
+			return false
+		}
+	}
+	return true
+}
+
+func operators(cond interface{}) (bson.M, bool) {
+	doc, ok := asDocument(cond)
+	if !ok || len(doc) == 0 {
+		return nil, false
+	}
+	for key := range doc {
+		if !strings.HasPrefix(key, "$") {
+			return nil, false
+		}
+	}
+	return doc, true
+}
+
+func matchOperator(values []interface{}, op string, operand interface{}) bool {
+	switch op {
+	case "$eq":
+		return containsEqual(values, operand)
+	case "$ne":
+		return !containsEqual(values, operand)
+	case "$in":
+		candidates, _ := asArray(operand)
+		for _, candidate := range candidates {
+			if containsEqual(values, candidate) {
+				return true
+			}
+		}
+		return false
+	case "$nin":
+		candidates, _ := asArray(operand)
+		for _, candidate := range candidates {
+			if containsEqual(values, candidate) {
+				return false
+			}
+		}
+		return true
+	case "$exists":
+		want, _ := operand.(bool)
+		return (len(values) > 0) == want
+	case "$gt", "$gte", "$lt", "$lte":
+		for _, v := range values {
+			c, ok := compare(v, operand)
+			if !ok {
+				continue
+			}
+			switch {
+			case op == "$gt" && c > 0, op == "$gte" && c >= 0, op == "$lt" && c < 0, op == "$lte" && c <= 0:
+				return true
+			}
+		}
+		return false
+	}
+	return false
+}
+
+func containsEqual(values []interface{}, operand interface{}) bool {
+	if len(values) == 0 {
+		return operand == nil
+	}
+	for _, v := range values {
+		if c, ok := compare(v, operand); ok && c == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// compare orders two decoded bson values of the same kind. ok is false when
+// the values cannot be compared with each other.
+func compare(a interface{}, b interface{}) (int, bool) {
+	if af, ok := number(a); ok {
+		bf, ok := number(b)
+		if !ok {
+			return 0, false
+		}
+		return compareFloat(af, bf), true
+	}
+
+	switch av := a.(type) {
+	case nil:
+		return 0, b == nil
+	case string:
+		bv, ok := b.(string)
+		return strings.Compare(av, bv), ok
+	case bool:
+		bv, ok := b.(bool)
+		if !ok {
+			return 0, false
+		}
+		if av == bv {
+			return 0, true
+		}
+		if !av {
+			return -1, true
+		}
+		return 1, true
+	case primitive.ObjectID:
+		bv, ok := b.(primitive.ObjectID)
+		return bytes.Compare(av[:], bv[:]), ok
+	case primitive.DateTime:
+		bv, ok := b.(primitive.DateTime)
+		return compareFloat(float64(av), float64(bv)), ok
+	case primitive.Timestamp:
+		bv, ok := b.(primitive.Timestamp)
+		return primitive.CompareTimestamp(av, bv), ok
+	}
+
+	if reflect.DeepEqual(a, b) {
+		return 0, true
+	}
+	return 0, false
+}
+
+func number(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+func compareFloat(a float64, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// setPath assigns a value at a dotted path, creating sub documents as needed.
+func setPath(doc bson.M, path string, value interface{}) {
+	parts := strings.Split(path, ".")
+	current := doc
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := asDocument(current[part])
+		if !ok {
+			next = bson.M{}
+		}
+		current[part] = next
+		current = next
+	}
+	current[parts[len(parts)-1]] = value
+}
+
+func unsetPath(doc bson.M, path string) {
+	parts := strings.Split(path, ".")
+	current := doc
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := asDocument(current[part])
+		if !ok {
+			return
+		}
+		current[part] = next
+		current = next
+	}
+	delete(current, parts[len(parts)-1])
+}
+
+// applyUpdate runs the update operators of a Mongo update document.
+func applyUpdate(doc bson.M, update bson.M, inserting bool) error {
+	for op, arg := range update {
+		fields, ok := asDocument(arg)
+		if !ok {
+			return fmt.Errorf("db: %s expects a document", op)
+		}
+		for path, value := range fields {
+			switch op {
+			case "$set":
+				setPath(doc, path, value)
+			case "$setOnInsert":
+				if inserting {
+					setPath(doc, path, value)
+				}
+			case "$unset":
+				unsetPath(doc, path)
+			case "$inc":
+				delta, ok := number(value)
+				if !ok {
+					return fmt.Errorf("db: cannot $inc by %v", value)
+				}
+				current := lookup(doc, strings.Split(path, "."))
+				if len(current) == 0 {
+					setPath(doc, path, value)
+					continue
+				}
+				existing, ok := number(current[0])
+				if !ok {
+					return fmt.Errorf("db: cannot $inc non numeric field %s", path)
+				}
+				setPath(doc, path, sumLike(current[0], existing+delta))
+			case "$push":
+				current := lookup(doc, strings.Split(path, "."))
+				arr := primitive.A{}
+				if len(current) > 0 {
+					arr, _ = asArray(current[0])
+				}
+				setPath(doc, path, append(arr, value))
+			default:
+				return fmt.Errorf("db: unsupported update operator %s", op)
+			}
+		}
+	}
+	return nil
+}
+
+// sumLike keeps the integer width of the field an $inc is applied to.
+func sumLike(original interface{}, sum float64) interface{} {
+	switch original.(type) {
+	case int32:
+		return int32(sum)
+	case int64:
+		return int64(sum)
+	}
+	return sum
+}
+
+// seedFromFilter builds the document an upsert starts from: the equality
+// conditions of the filter.
+func seedFromFilter(filter bson.M) bson.M {
+	doc := bson.M{}
+	for key, cond := range filter {
+		if strings.HasPrefix(key, "$") {
+			continue
+		}
+		if ops, ok := operators(cond); ok {
+			if eq, found := ops["$eq"]; found {
+				setPath(doc, key, eq)
+			}
+			continue
+		}
+		setPath(doc, key, cond)
+	}
+	return doc
+}
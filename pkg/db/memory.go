@@ -0,0 +1,471 @@
+package db
+
+import (
+	"awesomeProject/pkg/utils"
+	"bytes"
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MemoryStore implements Store without a database, for tests and local
+// development. Documents are kept as bson so they round trip exactly like
+// they would through Mongo.
+type MemoryStore struct {
+	mu          sync.RWMutex
+	collections map[string][]bson.M
+	unique      map[string][]string
+	feed        *ChangeFeed
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		collections: map[string][]bson.M{},
+		unique:      map[string][]string{},
+		feed:        NewChangeFeed(),
+	}
+}
+
+func (m *MemoryStore) Insert(collectionName string, document interface{}) error {
+	doc, err := toDocument(document)
+	if err != nil {
+		return err
+	}
+	if id, ok := doc["_id"]; !ok || id == nil {
+		doc["_id"] = primitive.NewObjectID()
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.indexOf(collectionName, bson.M{"_id": doc["_id"]}) >= 0 || m.conflicts(collectionName, doc, -1) {
+		return ErrDuplicateKey
+	}
+	m.collections[collectionName] = append(m.collections[collectionName], doc)
+	return m.feed.Publish(collectionName, "insert", doc["_id"], doc)
+}
+
+func (m *MemoryStore) Get(collectionName string, filter utils.KeyValue, document interface{}) error {
+	query, err := toDocument(filter)
+	if err != nil {
+		return err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	i := m.indexOf(collectionName, query)
+	if i < 0 {
+		return ErrNotFound
+	}
+	return decodeInto(m.collections[collectionName][i], document)
+}
+
+func (m *MemoryStore) GetAll(collectionName string, filter utils.KeyValue, documents interface{}) error {
+	query, err := toDocument(filter)
+	if err != nil {
+		return err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var found []bson.M
+	for _, doc := range m.collections[collectionName] {
+		if matches(doc, query) {
+			found = append(found, doc)
+		}
+	}
+	return decodeAll(found, documents)
+}
+
+func (m *MemoryStore) Find(collectionName string, filter utils.KeyValue, query Query, documents interface{}) (string, error) {
+	base, err := toDocument(filter)
+	if err != nil {
+		return "", err
+	}
+	where, err := query.keysetFilter(base)
+	if err != nil {
+		return "", err
+	}
+
+	m.mu.RLock()
+	var found []bson.M
+	for _, doc := range m.collections[collectionName] {
+		if matches(doc, where) {
+			found = append(found, doc)
+		}
+	}
+	m.mu.RUnlock()
+
+	sortDocuments(found, query)
+	if query.Limit > 0 && int64(len(found)) > query.Limit+1 {
+		found = found[:query.Limit+1]
+	}
+	found, next, err := query.page(found)
+	if err != nil {
+		return "", err
+	}
+	return next, decodeAll(found, documents)
+}
+
+func (m *MemoryStore) Count(collectionName string, filter utils.KeyValue) (int64, error) {
+	query, err := toDocument(filter)
+	if err != nil {
+		return 0, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var n int64
+	for _, doc := range m.collections[collectionName] {
+		if matches(doc, query) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (m *MemoryStore) UpdateOne(
+	collectionName string,
+	filter interface{},
+	document interface{},
+	opt options.UpdateOptions) (*mongo.UpdateResult, error) {
+	query, err := toDocument(filter)
+	if err != nil {
+		return nil, err
+	}
+	update, err := toDocument(document)
+	if err != nil {
+		return nil, err
+	}
+	upsert := opt.Upsert != nil && *opt.Upsert
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	i := m.indexOf(collectionName, query)
+	if i < 0 {
+		if !upsert {
+			return &mongo.UpdateResult{}, nil
+		}
+		doc, err := m.upsert(collectionName, query, update)
+		if err != nil {
+			return nil, err
+		}
+		return &mongo.UpdateResult{UpsertedCount: 1, UpsertedID: doc["_id"]}, nil
+	}
+
+	modified, err := m.update(collectionName, i, update)
+	if err != nil {
+		return nil, err
+	}
+	result := &mongo.UpdateResult{MatchedCount: 1}
+	if modified {
+		result.ModifiedCount = 1
+	}
+	return result, nil
+}
+
+func (m *MemoryStore) Replace(collectionName string, filter utils.KeyValue, document interface{}) error {
+	query, err := toDocument(filter)
+	if err != nil {
+		return err
+	}
+	doc, err := toDocument(document)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	i := m.indexOf(collectionName, query)
+	if i < 0 {
+		return nil
+	}
+	doc["_id"] = m.collections[collectionName][i]["_id"]
+	if m.conflicts(collectionName, doc, i) {
+		return ErrDuplicateKey
+	}
+	m.collections[collectionName][i] = doc
+	return m.feed.Publish(collectionName, "replace", doc["_id"], doc)
+}
+
+func (m *MemoryStore) Delete(collectionName string, filter utils.KeyValue) error {
+	query, err := toDocument(filter)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	i := m.indexOf(collectionName, query)
+	if i < 0 {
+		return nil
+	}
+	docs := m.collections[collectionName]
+	id := docs[i]["_id"]
+	m.collections[collectionName] = append(docs[:i:i], docs[i+1:]...)
+	return m.feed.Publish(collectionName, "delete", id, nil)
+}
+
+func (m *MemoryStore) FindOneAndUpdate(
+	collectionName string,
+	filter utils.KeyValue,
+	document interface{},
+	opt options.FindOneAndUpdateOptions) (bson.M, error) {
+	query, err := toDocument(filter)
+	if err != nil {
+		return nil, err
+	}
+	update, err := toDocument(document)
+	if err != nil {
+		return nil, err
+	}
+	upsert := opt.Upsert != nil && *opt.Upsert
+	returnAfter := opt.ReturnDocument != nil && *opt.ReturnDocument == options.After
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	i := m.indexOf(collectionName, query)
+	if i < 0 {
+		if !upsert {
+			return nil, ErrNotFound
+		}
+		doc, err := m.upsert(collectionName, query, update)
+		if err != nil || !returnAfter {
+			return nil, err
+		}
+		return toDocument(doc)
+	}
+
+	before, err := toDocument(m.collections[collectionName][i])
+	if err != nil {
+		return nil, err
+	}
+	if _, err := m.update(collectionName, i, update); err != nil {
+		return nil, err
+	}
+	if returnAfter {
+		return toDocument(m.collections[collectionName][i])
+	}
+	return before, nil
+}
+
+// Watch streams inserts, updates, replaces and deletes on a collection.
+// waitTime is accepted for parity with MongoStore; the stream simply blocks
+// until the next change or until the context passed to Next is done. The
+// feed keeps no history, so resumeAfter is ignored and the stream starts
+// from now.
+func (m *MemoryStore) Watch(collectionName string, waitTime time.Duration, resumeAfter bson.Raw) (ChangeStream, error) {
+	return m.feed.Watch(collectionName), nil
+}
+
+// Transaction holds the write lock while fn runs, so it is serialised with
+// every other access, and undoes the writes of a failed fn by restoring the
+// collections. fn must only use tx.
+func (m *MemoryStore) Transaction(fn func(tx Store) error) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	saved := make(map[string][]bson.M, len(m.collections))
+	for name, docs := range m.collections {
+		saved[name] = append([]bson.M(nil), docs...)
+	}
+	// tx shares the collections but has a lock of its own, which nobody
+	// else can take while m's is held.
+	tx := &MemoryStore{collections: m.collections, unique: m.unique, feed: m.feed.Hold()}
+	if err := fn(tx); err != nil {
+		for name := range m.collections {
+			if _, ok := saved[name]; !ok {
+				delete(m.collections, name)
+			}
+		}
+		for name, docs := range saved {
+			m.collections[name] = docs
+		}
+		return err
+	}
+	return tx.feed.Release()
+}
+
+func (m *MemoryStore) EnsureUniqueIndex(collectionName string, field string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	seen := map[string]bool{}
+	for _, doc := range m.collections[collectionName] {
+		value, ok := uniqueValue(doc, field)
+		if !ok {
+			continue
+		}
+		if seen[value] {
+			return ErrDuplicateKey
+		}
+		seen[value] = true
+	}
+	for _, existing := range m.unique[collectionName] {
+		if existing == field {
+			return nil
+		}
+	}
+	m.unique[collectionName] = append(m.unique[collectionName], field)
+	return nil
+}
+
+func (m *MemoryStore) Close() error {
+	return nil
+}
+
+// conflicts reports whether doc shares a unique field value with another
+// document than the one at position skip. Only top-level fields can be
+// unique. Callers must hold the lock.
+func (m *MemoryStore) conflicts(collectionName string, doc bson.M, skip int) bool {
+	for _, field := range m.unique[collectionName] {
+		value, ok := uniqueValue(doc, field)
+		if !ok {
+			continue
+		}
+		for i, other := range m.collections[collectionName] {
+			if otherValue, ok := uniqueValue(other, field); ok && i != skip && otherValue == value {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// uniqueValue is the value a unique index covers: non-empty strings only.
+func uniqueValue(doc bson.M, field string) (string, bool) {
+	value, ok := doc[field].(string)
+	return value, ok && value != ""
+}
+
+// updatedFields lists the top level fields of after that differ from before.
+func updatedFields(before bson.M, after bson.M) bson.M {
+	fields := bson.M{}
+	for key, value := range after {
+		if old, ok := before[key]; !ok || !reflect.DeepEqual(old, value) {
+			fields[key] = value
+		}
+	}
+	return fields
+}
+
+// indexOf returns the position of the first document matching the filter,
+// or -1. Callers must hold the lock.
+func (m *MemoryStore) indexOf(collectionName string, filter bson.M) int {
+	for i, doc := range m.collections[collectionName] {
+		if matches(doc, filter) {
+			return i
+		}
+	}
+	return -1
+}
+
+// update applies an update document in place and reports whether the
+// stored document changed. Callers must hold the write lock.
+func (m *MemoryStore) update(collectionName string, i int, update bson.M) (bool, error) {
+	current := m.collections[collectionName][i]
+	doc, err := toDocument(current)
+	if err != nil {
+		return false, err
+	}
+	if err := applyUpdate(doc, update, false); err != nil {
+		return false, err
+	}
+
+	before, _ := bson.Marshal(current)
+	after, err := bson.Marshal(doc)
+	if err != nil {
+		return false, err
+	}
+	if bytes.Equal(before, after) {
+		return false, nil
+	}
+	if m.conflicts(collectionName, doc, i) {
+		return false, ErrDuplicateKey
+	}
+	m.collections[collectionName][i] = doc
+	return true, m.feed.PublishUpdate(collectionName, doc["_id"], updatedFields(current, doc), doc)
+}
+
+// upsert inserts the document an update with upsert=true creates.
+// Callers must hold the write lock.
+func (m *MemoryStore) upsert(collectionName string, filter bson.M, update bson.M) (bson.M, error) {
+	doc := seedFromFilter(filter)
+	if err := applyUpdate(doc, update, true); err != nil {
+		return nil, err
+	}
+	if _, ok := doc["_id"]; !ok {
+		doc["_id"] = primitive.NewObjectID()
+	}
+	doc, err := toDocument(doc)
+	if err != nil {
+		return nil, err
+	}
+	if m.conflicts(collectionName, doc, -1) {
+		return nil, ErrDuplicateKey
+	}
+	m.collections[collectionName] = append(m.collections[collectionName], doc)
+	return doc, m.feed.Publish(collectionName, "insert", doc["_id"], doc)
+}
+
+// sortDocuments orders documents like the sort document of the query.
+// Missing values sort first, as in Mongo.
+func sortDocuments(docs []bson.M, query Query) {
+	field := strings.Split(query.sortField(), ".")
+	key := func(doc bson.M) interface{} {
+		if values := lookup(doc, field); len(values) > 0 {
+			return values[0]
+		}
+		return nil
+	}
+	sort.SliceStable(docs, func(i, j int) bool {
+		a, b := key(docs[i]), key(docs[j])
+		c, _ := compare(a, b)
+		switch {
+		case a == nil && b != nil:
+			c = -1
+		case a != nil && b == nil:
+			c = 1
+		}
+		if c == 0 {
+			c, _ = compare(docs[i]["_id"], docs[j]["_id"])
+		}
+		if query.Descending {
+			return c > 0
+		}
+		return c < 0
+	})
+}
+
+// decodeAll decodes documents into the slice documents points to.
+func decodeAll(docs []bson.M, documents interface{}) error {
+	target := reflect.ValueOf(documents)
+	if target.Kind() != reflect.Ptr || target.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("db: GetAll expects a pointer to a slice, got %T", documents)
+	}
+	slice := target.Elem()
+	elemType := slice.Type().Elem()
+	result := reflect.MakeSlice(slice.Type(), 0, len(docs))
+
+	for _, doc := range docs {
+		if elemType.Kind() == reflect.Ptr {
+			elem := reflect.New(elemType.Elem())
+			if err := decodeInto(doc, elem.Interface()); err != nil {
+				return err
+			}
+			result = reflect.Append(result, elem)
+			continue
+		}
+		elem := reflect.New(elemType)
+		if err := decodeInto(doc, elem.Interface()); err != nil {
+			return err
+		}
+		result = reflect.Append(result, elem.Elem())
+	}
+	slice.Set(result)
+	return nil
+}
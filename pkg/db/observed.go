@@ -0,0 +1,117 @@
+package db
+
+import (
+	"awesomeProject/pkg/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+// Observer is told about every store operation once it returns, with the
+// collection it ran on and its error. Transactions are reported as a
+// whole with an empty collection, besides the operations within.
+type Observer func(operation string, collectionName string, took time.Duration, err error)
+
+// ObservedStore reports the operations of a Store to an Observer, for
+// metrics.
+type ObservedStore struct {
+	store   Store
+	observe Observer
+}
+
+func NewObservedStore(store Store, observe Observer) *ObservedStore {
+	return &ObservedStore{store: store, observe: observe}
+}
+
+func (s *ObservedStore) done(operation string, collectionName string, start time.Time, err error) {
+	s.observe(operation, collectionName, time.Since(start), err)
+}
+
+func (s *ObservedStore) Insert(collectionName string, document interface{}) error {
+	start := time.Now()
+	err := s.store.Insert(collectionName, document)
+	s.done("insert", collectionName, start, err)
+	return err
+}
+
+func (s *ObservedStore) Get(collectionName string, filter utils.KeyValue, document interface{}) error {
+	start := time.Now()
+	err := s.store.Get(collectionName, filter, document)
+	s.done("get", collectionName, start, err)
+	return err
+}
+
+func (s *ObservedStore) GetAll(collectionName string, filter utils.KeyValue, documents interface{}) error {
+	start := time.Now()
+	err := s.store.GetAll(collectionName, filter, documents)
+	s.done("get_all", collectionName, start, err)
+	return err
+}
+
+func (s *ObservedStore) Find(collectionName string, filter utils.KeyValue, query Query, documents interface{}) (string, error) {
+	start := time.Now()
+	next, err := s.store.Find(collectionName, filter, query, documents)
+	s.done("find", collectionName, start, err)
+	return next, err
+}
+
+func (s *ObservedStore) Count(collectionName string, filter utils.KeyValue) (int64, error) {
+	start := time.Now()
+	count, err := s.store.Count(collectionName, filter)
+	s.done("count", collectionName, start, err)
+	return count, err
+}
+
+func (s *ObservedStore) UpdateOne(collectionName string, filter interface{}, document interface{}, opt options.UpdateOptions) (*mongo.UpdateResult, error) {
+	start := time.Now()
+	result, err := s.store.UpdateOne(collectionName, filter, document, opt)
+	s.done("update_one", collectionName, start, err)
+	return result, err
+}
+
+func (s *ObservedStore) Replace(collectionName string, filter utils.KeyValue, document interface{}) error {
+	start := time.Now()
+	err := s.store.Replace(collectionName, filter, document)
+	s.done("replace", collectionName, start, err)
+	return err
+}
+
+func (s *ObservedStore) Delete(collectionName string, filter utils.KeyValue) error {
+	start := time.Now()
+	err := s.store.Delete(collectionName, filter)
+	s.done("delete", collectionName, start, err)
+	return err
+}
+
+func (s *ObservedStore) FindOneAndUpdate(collectionName string, filter utils.KeyValue, document interface{}, opt options.FindOneAndUpdateOptions) (bson.M, error) {
+	start := time.Now()
+	result, err := s.store.FindOneAndUpdate(collectionName, filter, document, opt)
+	s.done("find_one_and_update", collectionName, start, err)
+	return result, err
+}
+
+// Watch reports opening the change stream; reading it is not observed.
+func (s *ObservedStore) Watch(collectionName string, waitTime time.Duration, resumeAfter bson.Raw) (ChangeStream, error) {
+	start := time.Now()
+	stream, err := s.store.Watch(collectionName, waitTime, resumeAfter)
+	s.done("watch", collectionName, start, err)
+	return stream, err
+}
+
+func (s *ObservedStore) Transaction(fn func(tx Store) error) error {
+	start := time.Now()
+	err := s.store.Transaction(func(tx Store) error {
+		return fn(NewObservedStore(tx, s.observe))
+	})
+	s.done("transaction", "", start, err)
+	return err
+}
+
+func (s *ObservedStore) EnsureUniqueIndex(collectionName string, field string) error {
+	return s.store.EnsureUniqueIndex(collectionName, field)
+}
+
+func (s *ObservedStore) Close() error {
+	return s.store.Close()
+}
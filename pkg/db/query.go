@@ -0,0 +1,124 @@
+package db
+
+import (
+	"encoding/base64"
+	"errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"strings"
+)
+
+// ErrInvalidPageToken is returned for page tokens that were not produced by
+// a query with the same sort.
+var ErrInvalidPageToken = errors.New("db: invalid page token")
+
+// Query describes one page of a sorted, filtered read. Paging is keyset
+// based: the page token remembers the sort value and _id of the last
+// document returned, so pages stay stable while documents are inserted.
+type Query struct {
+	// Sort is the field to order by; documents with equal values are
+	// ordered by _id. Defaults to _id.
+	Sort       string
+	Descending bool
+	// Limit is the page size; 0 returns every remaining document.
+	Limit int64
+	// PageToken continues after the page that returned it.
+	PageToken string
+}
+
+func (q Query) sortField() string {
+	if q.Sort == "" {
+		return "_id"
+	}
+	return q.Sort
+}
+
+type pageToken struct {
+	Sort       string      `bson:"s"`
+	Descending bool        `bson:"d"`
+	Value      interface{} `bson:"v"`
+	ID         interface{} `bson:"i"`
+}
+
+// NewPageToken encodes the position after a document with the given sort
+// value and id.
+func (q Query) NewPageToken(value interface{}, id interface{}) (string, error) {
+	raw, err := bson.Marshal(pageToken{Sort: q.sortField(), Descending: q.Descending, Value: value, ID: id})
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// After decodes the page token, returning the sort value and id of the last
+// document of the previous page. ok is false when there is no token.
+func (q Query) After() (value interface{}, id interface{}, ok bool, err error) {
+	if q.PageToken == "" {
+		return nil, nil, false, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(q.PageToken)
+	if err != nil {
+		return nil, nil, false, ErrInvalidPageToken
+	}
+	var token pageToken
+	if err := bson.Unmarshal(raw, &token); err != nil {
+		return nil, nil, false, ErrInvalidPageToken
+	}
+	if token.Sort != q.sortField() || token.Descending != q.Descending {
+		return nil, nil, false, ErrInvalidPageToken
+	}
+	return token.Value, token.ID, true, nil
+}
+
+// keysetFilter narrows filter to the documents after the page token.
+func (q Query) keysetFilter(filter bson.M) (bson.M, error) {
+	value, id, ok, err := q.After()
+	if err != nil || !ok {
+		return filter, err
+	}
+	op := "$gt"
+	if q.Descending {
+		op = "$lt"
+	}
+	field := q.sortField()
+	after := bson.M{field: bson.M{op: value}}
+	if field != "_id" {
+		after = bson.M{"$or": bson.A{
+			bson.M{field: bson.M{op: value}},
+			bson.M{field: bson.M{"$eq": value}, "_id": bson.M{op: id}},
+		}}
+	}
+	if len(filter) == 0 {
+		return after, nil
+	}
+	return bson.M{"$and": bson.A{filter, after}}, nil
+}
+
+// sortOrder is the Mongo sort document of the query.
+func (q Query) sortOrder() bson.D {
+	direction := 1
+	if q.Descending {
+		direction = -1
+	}
+	field := q.sortField()
+	sort := bson.D{{Key: field, Value: direction}}
+	if field != "_id" {
+		sort = append(sort, bson.E{Key: "_id", Value: direction})
+	}
+	return sort
+}
+
+// page trims the Limit+1 documents a store fetched to one page and returns
+// the token of the next page, empty on the last page.
+func (q Query) page(docs []bson.M) ([]bson.M, string, error) {
+	if q.Limit <= 0 || int64(len(docs)) <= q.Limit {
+		return docs, "", nil
+	}
+	docs = docs[:q.Limit]
+	last := docs[len(docs)-1]
+	var value interface{}
+	if values := lookup(last, strings.Split(q.sortField(), ".")); len(values) > 0 {
+		value = values[0]
+	}
+	token, err := q.NewPageToken(value, last["_id"])
+	return docs, token, err
+}
@@ -0,0 +1,48 @@
+package db
+
+import (
+	"awesomeProject/pkg/utils"
+	"errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+var (
+	// ErrNotFound is returned when a lookup matches no document.
+	ErrNotFound = errors.New("db: document not found")
+	// ErrDuplicateKey is returned when a write violates a unique key.
+	ErrDuplicateKey = errors.New("db: duplicate key")
+	// ErrHistoryLost is returned when a change stream cannot resume because
+	// the changes after its resume token are no longer kept.
+	ErrHistoryLost = errors.New("db: change stream history lost")
+)
+
+// Store is the set of document operations the repositories are built on.
+// MongoStore talks to a real cluster, MemoryStore keeps everything in process.
+type Store interface {
+	Insert(collectionName string, document interface{}) error
+	Get(collectionName string, filter utils.KeyValue, document interface{}) error
+	GetAll(collectionName string, filter utils.KeyValue, documents interface{}) error
+	// Find reads one page of documents and returns the token of the next
+	// page, empty when there are no more documents.
+	Find(collectionName string, filter utils.KeyValue, query Query, documents interface{}) (string, error)
+	Count(collectionName string, filter utils.KeyValue) (int64, error)
+	UpdateOne(collectionName string, filter interface{}, document interface{}, opt options.UpdateOptions) (*mongo.UpdateResult, error)
+	Replace(collectionName string, filter utils.KeyValue, document interface{}) error
+	Delete(collectionName string, filter utils.KeyValue) error
+	FindOneAndUpdate(collectionName string, filter utils.KeyValue, document interface{}, opt options.FindOneAndUpdateOptions) (bson.M, error)
+	// Watch streams inserts, updates, replaces and deletes. A non-nil
+	// resumeAfter continues after the change event with that _id.
+	Watch(collectionName string, waitTime time.Duration, resumeAfter bson.Raw) (ChangeStream, error)
+	// Transaction runs fn with a store whose writes are committed together
+	// when fn returns nil and discarded otherwise. Watchers see the
+	// changes once they are committed.
+	Transaction(fn func(tx Store) error) error
+	// EnsureUniqueIndex makes field unique among the documents where it is
+	// a non-empty string. It fails with ErrDuplicateKey when stored
+	// documents already share a value.
+	EnsureUniqueIndex(collectionName string, field string) error
+	Close() error
+}
@@ -0,0 +1,128 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// ErrCurrencyMismatch is returned when combining amounts in different
+// currencies.
+var ErrCurrencyMismatch = errors.New("money: currency mismatch")
+
+// ErrOverflow is returned when a result does not fit in an int64 of minor
+// units.
+var ErrOverflow = errors.New("money: amount out of range")
+
+// Money is an amount in the minor units of its currency, e.g. cents for
+// EUR, so sums never pick up floating point errors.
+type Money struct {
+	Amount   int64  `bson:"amount" json:"amount" validate:"gte=0"`
+	Currency string `bson:"currency" json:"currency" validate:"required,len=3,alpha,uppercase"`
+}
+
+// exponents lists the currencies whose minor unit is not a hundredth.
+var exponents = map[string]int{
+	"BHD": 3,
+	"CLP": 0,
+	"ISK": 0,
+	"JPY": 0,
+	"KRW": 0,
+	"KWD": 3,
+	"OMR": 3,
+	"TND": 3,
+	"VND": 0,
+}
+
+func New(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// Zero is no money in currency.
+func Zero(currency string) Money {
+	return Money{Currency: currency}
+}
+
+// FromMajor converts an amount in major units, such as a float price stored
+// before amounts were kept in minor units, rounding to the nearest minor
+// unit.
+func FromMajor(value float64, currency string) Money {
+	scale := math.Pow10(Exponent(currency))
+	return Money{Amount: int64(math.Round(value * scale)), Currency: currency}
+}
+
+// Exponent is the number of minor unit digits of currency.
+func Exponent(currency string) int {
+	if exponent, ok := exponents[strings.ToUpper(currency)]; ok {
+		return exponent
+	}
+	return 2
+}
+
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// Add sums two amounts of the same currency.
+func (m Money) Add(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+	sum := m.Amount + other.Amount
+	if (other.Amount > 0 && sum < m.Amount) || (other.Amount < 0 && sum > m.Amount) {
+		return Money{}, fmt.Errorf("%w: %s + %s", ErrOverflow, m, other)
+	}
+	return Money{Amount: sum, Currency: m.Currency}, nil
+}
+
+// Mul multiplies the amount by a quantity.
+func (m Money) Mul(quantity int64) (Money, error) {
+	product, ok := mul(m.Amount, quantity)
+	if !ok {
+		return Money{}, fmt.Errorf("%w: %s * %d", ErrOverflow, m, quantity)
+	}
+	return Money{Amount: product, Currency: m.Currency}, nil
+}
+
+// Rate takes a share of the amount given in basis points, so 1950 is 19.5%.
+// The result is rounded half away from zero to the nearest minor unit.
+func (m Money) Rate(basisPoints int64) (Money, error) {
+	product, ok := mul(m.Amount, basisPoints)
+	if !ok {
+		return Money{}, fmt.Errorf("%w: %d basis points of %s", ErrOverflow, basisPoints, m)
+	}
+	share := product / 10000
+	if remainder := product % 10000; remainder >= 5000 {
+		share++
+	} else if remainder <= -5000 {
+		share--
+	}
+	return Money{Amount: share, Currency: m.Currency}, nil
+}
+
+// mul multiplies a and b, reporting whether the product fits in an int64.
+func mul(a int64, b int64) (int64, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	product := a * b
+	if product/b != a || (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) {
+		return 0, false
+	}
+	return product, true
+}
+
+// String formats the amount in major units, e.g. "12.34 EUR".
+func (m Money) String() string {
+	exponent := Exponent(m.Currency)
+	if exponent == 0 {
+		return fmt.Sprintf("%d %s", m.Amount, m.Currency)
+	}
+	scale := int64(math.Pow10(exponent))
+	sign, amount := "", m.Amount
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+	return fmt.Sprintf("%s%d.%0*d %s", sign, amount/scale, exponent, amount%scale, m.Currency)
+}
@@ -0,0 +1,44 @@
+package money
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestArithmetic(t *testing.T) {
+	eur := func(amount int64) Money { return New(amount, "EUR") }
+	tests := []struct {
+		name string
+		op   func() (Money, error)
+		want Money
+		err  error
+	}{
+		{name: "add", op: func() (Money, error) { return eur(150).Add(eur(250)) }, want: eur(400)},
+		{name: "add negative", op: func() (Money, error) { return eur(150).Add(eur(-250)) }, want: eur(-100)},
+		{name: "add overflow", op: func() (Money, error) { return eur(math.MaxInt64).Add(eur(1)) }, err: ErrOverflow},
+		{name: "add underflow", op: func() (Money, error) { return eur(math.MinInt64).Add(eur(-1)) }, err: ErrOverflow},
+		{name: "add currencies", op: func() (Money, error) { return eur(1).Add(New(1, "USD")) }, err: ErrCurrencyMismatch},
+		{name: "mul", op: func() (Money, error) { return eur(250).Mul(3) }, want: eur(750)},
+		{name: "mul zero", op: func() (Money, error) { return eur(math.MaxInt64).Mul(0) }, want: eur(0)},
+		{name: "mul overflow", op: func() (Money, error) { return eur(math.MaxInt64 / 2).Mul(10000) }, err: ErrOverflow},
+		{name: "mul min by -1", op: func() (Money, error) { return eur(math.MinInt64).Mul(-1) }, err: ErrOverflow},
+		{name: "rate", op: func() (Money, error) { return eur(1499).Rate(1900) }, want: eur(285)},
+		{name: "rate rounds half up", op: func() (Money, error) { return eur(50).Rate(1000) }, want: eur(5)},
+		{name: "rate overflow", op: func() (Money, error) { return eur(math.MaxInt64 / 100).Rate(1900) }, err: ErrOverflow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.op()
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Errorf("err = %v, want %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("got %v, %v; want %v", got, err, tt.want)
+			}
+		})
+	}
+}